@@ -46,6 +46,8 @@ dependencies {
 
 tasks.withType<Test> {
     useJUnitPlatform()
+    // Unit tests run without a database: skip the startup connection retry
+    systemProperty("app.db.connect.max-attempts", "0")
     finalizedBy(tasks.jacocoTestReport)
 }
 
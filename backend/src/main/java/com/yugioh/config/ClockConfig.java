@@ -0,0 +1,18 @@
+package com.yugioh.config;
+
+import org.springframework.context.annotation.Bean;
+import org.springframework.context.annotation.Configuration;
+
+import java.time.Clock;
+
+/**
+ * The clock behind server-side timestamps (health and error responses), a
+ * bean so tests can swap in a fixed one.
+ */
+@Configuration
+public class ClockConfig {
+    @Bean
+    public Clock clock() {
+        return Clock.systemUTC();
+    }
+}
@@ -0,0 +1,87 @@
+package com.yugioh.config;
+
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.beans.factory.config.BeanPostProcessor;
+import org.springframework.stereotype.Component;
+
+import javax.sql.DataSource;
+import java.sql.Connection;
+import java.sql.SQLException;
+
+/**
+ * Waits for the database to accept connections before the DataSource is handed
+ * to the rest of the application, so a database that is briefly unavailable
+ * during a deploy doesn't fail startup. Retries back off exponentially (capped
+ * at {@link #MAX_INTERVAL_MS}); a max attempts of 0 disables the check.
+ */
+@Component
+public class DatabaseConnectionRetry implements BeanPostProcessor {
+    private static final Logger log = LoggerFactory.getLogger(DatabaseConnectionRetry.class);
+
+    /** Upper bound for the delay between two attempts. */
+    static final long MAX_INTERVAL_MS = 30_000;
+
+    @FunctionalInterface
+    interface Sleeper {
+        void sleep(long millis) throws InterruptedException;
+    }
+
+    private final int maxAttempts;
+    private final long intervalMs;
+    private final Sleeper sleeper;
+
+    public DatabaseConnectionRetry(
+            @Value("${app.db.connect.max-attempts}") int maxAttempts,
+            @Value("${app.db.connect.interval-ms}") long intervalMs) {
+        this(maxAttempts, intervalMs, Thread::sleep);
+    }
+
+    DatabaseConnectionRetry(int maxAttempts, long intervalMs, Sleeper sleeper) {
+        this.maxAttempts = maxAttempts;
+        this.intervalMs = intervalMs;
+        this.sleeper = sleeper;
+    }
+
+    @Override
+    public Object postProcessAfterInitialization(Object bean, String beanName) {
+        if (bean instanceof DataSource dataSource && maxAttempts > 0) {
+            waitForConnection(dataSource);
+        }
+        return bean;
+    }
+
+    void waitForConnection(DataSource dataSource) {
+        for (int attempt = 1; ; attempt++) {
+            try (Connection ignored = dataSource.getConnection()) {
+                if (attempt > 1) {
+                    log.info("Database connection established after {} attempts", attempt);
+                }
+                return;
+            } catch (SQLException e) {
+                if (attempt >= maxAttempts) {
+                    throw new IllegalStateException("Database unavailable after " + attempt + " attempts", e);
+                }
+                long delay = backoff(attempt);
+                log.warn("Database connection attempt {}/{} failed: {}. Retrying in {} ms",
+                    attempt, maxAttempts, e.getMessage(), delay);
+                pause(delay);
+            }
+        }
+    }
+
+    long backoff(int attempt) {
+        long delay = intervalMs << Math.min(attempt - 1, 20);
+        return Math.min(delay, MAX_INTERVAL_MS);
+    }
+
+    private void pause(long millis) {
+        try {
+            sleeper.sleep(millis);
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+            throw new IllegalStateException("Interrupted while waiting for the database", e);
+        }
+    }
+}
@@ -0,0 +1,81 @@
+package com.yugioh.config;
+
+import com.yugioh.exception.DatabaseUnavailableException;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.dao.DataAccessResourceFailureException;
+import org.springframework.stereotype.Component;
+import org.springframework.transaction.CannotCreateTransactionException;
+
+import java.sql.SQLException;
+import java.util.function.Supplier;
+
+/**
+ * Runs a read query and, if it fails on a dropped connection (e.g. Postgres
+ * restarting during maintenance), retries it once after a short delay so the
+ * pool can hand out a fresh connection. A second connection failure surfaces
+ * as {@link DatabaseUnavailableException} (503) instead of a generic 500.
+ */
+@Component
+public class DatabaseReadRetry {
+    private static final Logger log = LoggerFactory.getLogger(DatabaseReadRetry.class);
+
+    /** SQLState class for connection exceptions. */
+    private static final String CONNECTION_EXCEPTION_CLASS = "08";
+
+    private final long delayMs;
+    private final DatabaseConnectionRetry.Sleeper sleeper;
+
+    public DatabaseReadRetry(@Value("${app.db.retry.delay-ms}") long delayMs) {
+        this(delayMs, Thread::sleep);
+    }
+
+    DatabaseReadRetry(long delayMs, DatabaseConnectionRetry.Sleeper sleeper) {
+        this.delayMs = delayMs;
+        this.sleeper = sleeper;
+    }
+
+    public <T> T withRetry(Supplier<T> query) {
+        try {
+            return query.get();
+        } catch (RuntimeException e) {
+            if (!isConnectionError(e)) {
+                throw e;
+            }
+            log.warn("Database connection lost: {}. Retrying in {} ms", e.getMessage(), delayMs);
+            pause();
+        }
+
+        try {
+            return query.get();
+        } catch (RuntimeException e) {
+            if (isConnectionError(e)) {
+                throw new DatabaseUnavailableException("Database is temporarily unavailable", e);
+            }
+            throw e;
+        }
+    }
+
+    static boolean isConnectionError(Throwable e) {
+        for (Throwable t = e; t != null; t = t.getCause()) {
+            if (t instanceof DataAccessResourceFailureException || t instanceof CannotCreateTransactionException) {
+                return true;
+            }
+            if (t instanceof SQLException sql && sql.getSQLState() != null
+                    && sql.getSQLState().startsWith(CONNECTION_EXCEPTION_CLASS)) {
+                return true;
+            }
+        }
+        return false;
+    }
+
+    private void pause() {
+        try {
+            sleeper.sleep(delayMs);
+        } catch (InterruptedException e) {
+            Thread.currentThread().interrupt();
+            throw new DatabaseUnavailableException("Interrupted while waiting for the database", e);
+        }
+    }
+}
@@ -0,0 +1,49 @@
+package com.yugioh.config;
+
+import com.zaxxer.hikari.HikariConfig;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.beans.factory.config.BeanPostProcessor;
+import org.springframework.stereotype.Component;
+
+import java.util.LinkedHashMap;
+import java.util.Map;
+
+/**
+ * Adds PostgreSQL SSL settings (mode, CA cert, client cert and key) to the
+ * connection pool's driver properties so {@code require}/{@code verify-full}
+ * work against managed databases. Unset values are left out entirely so the
+ * driver keeps its own defaults.
+ */
+@Component
+public class DatabaseSslCustomizer implements BeanPostProcessor {
+    private final Map<String, String> sslProperties = new LinkedHashMap<>();
+
+    public DatabaseSslCustomizer(
+            @Value("${app.db.ssl.mode}") String mode,
+            @Value("${app.db.ssl.root-cert}") String rootCert,
+            @Value("${app.db.ssl.cert}") String cert,
+            @Value("${app.db.ssl.key}") String key) {
+        putIfSet("sslmode", mode);
+        putIfSet("sslrootcert", rootCert);
+        putIfSet("sslcert", cert);
+        putIfSet("sslkey", key);
+    }
+
+    public Map<String, String> getSslProperties() {
+        return sslProperties;
+    }
+
+    @Override
+    public Object postProcessBeforeInitialization(Object bean, String beanName) {
+        if (bean instanceof HikariConfig hikariConfig) {
+            sslProperties.forEach(hikariConfig::addDataSourceProperty);
+        }
+        return bean;
+    }
+
+    private void putIfSet(String name, String value) {
+        if (value != null && !value.isBlank()) {
+            sslProperties.put(name, value.trim());
+        }
+    }
+}
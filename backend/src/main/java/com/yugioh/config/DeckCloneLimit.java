@@ -0,0 +1,60 @@
+package com.yugioh.config;
+
+import com.yugioh.exception.ForbiddenException;
+import com.yugioh.exception.RateLimitedException;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.stereotype.Component;
+
+import java.time.Clock;
+import java.time.Duration;
+import java.time.Instant;
+
+/**
+ * Gate for POST /decks/{id}/clone. Decks have no owners and the API has no
+ * authentication, so cloning is off unless DECK_CLONE_ENABLED is set, and
+ * even then at most DECK_CLONES_PER_MINUTE clones are created per minute
+ * across all clients so the decks table cannot be filled in a loop.
+ */
+@Component
+public class DeckCloneLimit {
+    private static final Duration WINDOW = Duration.ofMinutes(1);
+
+    private final boolean enabled;
+    private final int clonesPerMinute;
+    private final Clock clock;
+
+    private Instant windowStart = Instant.MIN;
+    private int clonesInWindow;
+
+    public DeckCloneLimit(
+            @Value("${app.decks.clone-enabled}") boolean enabled,
+            @Value("${app.decks.clones-per-minute}") int clonesPerMinute,
+            Clock clock) {
+        if (clonesPerMinute < 1) {
+            throw new IllegalArgumentException("app.decks.clones-per-minute must be at least 1, got " + clonesPerMinute);
+        }
+        this.enabled = enabled;
+        this.clonesPerMinute = clonesPerMinute;
+        this.clock = clock;
+    }
+
+    /**
+     * Count one clone against the current minute, or throw if cloning is
+     * disabled (403) or the minute's budget is spent (429).
+     */
+    public synchronized void acquire() {
+        if (!enabled) {
+            throw new ForbiddenException("Deck cloning is disabled on this server");
+        }
+        Instant now = clock.instant();
+        if (!now.isBefore(windowStart.plus(WINDOW))) {
+            windowStart = now;
+            clonesInWindow = 0;
+        }
+        if (clonesInWindow >= clonesPerMinute) {
+            throw new RateLimitedException(String.format(
+                "At most %d decks can be cloned per minute, try again later", clonesPerMinute));
+        }
+        clonesInWindow++;
+    }
+}
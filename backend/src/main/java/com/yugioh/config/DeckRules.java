@@ -1,14 +1,87 @@
 package com.yugioh.config;
 
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.stereotype.Component;
+
+import java.util.List;
+import java.util.Map;
+import java.util.function.Function;
+import java.util.stream.Collectors;
+
 /**
- * Deck construction rules: max size and max copies per card.
+ * Deck construction rules: cost budget, size range and max copies per card.
+ * The size range (MIN_DECK_SIZE/MAX_DECK_SIZE) and copy limit
+ * (MAX_CARD_COPIES) are configurable so other formats can run without a
+ * rebuild.
  */
-public final class DeckRules {
-    /** Maximum number of cards in a deck. */
-    public static final int MAX_DECK_SIZE = 40;
+@Component
+public class DeckRules {
+    /** Default minimum number of cards in a deck. */
+    public static final int DEFAULT_MIN_DECK_SIZE = 40;
+
+    /** Default maximum number of cards in a deck. */
+    public static final int DEFAULT_MAX_DECK_SIZE = 60;
+
+    /** Default maximum number of copies of the same card allowed per deck. */
+    public static final int DEFAULT_MAX_COPIES_PER_CARD = 3;
+
+    private final int maxCopiesPerCard;
+    private final int minDeckSize;
+    private final int maxDeckSize;
+
+    public DeckRules(
+            @Value("${deck.max-card-copies}") int maxCopiesPerCard,
+            @Value("${deck.min-size}") int minDeckSize,
+            @Value("${deck.max-size}") int maxDeckSize) {
+        if (maxCopiesPerCard < 1) {
+            throw new IllegalArgumentException("deck.max-card-copies must be at least 1, got " + maxCopiesPerCard);
+        }
+        if (minDeckSize < 0) {
+            throw new IllegalArgumentException("deck.min-size must not be negative, got " + minDeckSize);
+        }
+        if (maxDeckSize < Math.max(minDeckSize, 1)) {
+            throw new IllegalArgumentException(String.format(
+                "deck.max-size must be at least 1 and at least deck.min-size (%d), got %d", minDeckSize, maxDeckSize));
+        }
+        this.maxCopiesPerCard = maxCopiesPerCard;
+        this.minDeckSize = minDeckSize;
+        this.maxDeckSize = maxDeckSize;
+    }
+
+    public int getMaxCopiesPerCard() {
+        return maxCopiesPerCard;
+    }
+
+    public int getMinDeckSize() {
+        return minDeckSize;
+    }
 
-    /** Maximum number of copies of the same card allowed per deck. */
-    public static final int MAX_COPIES_PER_CARD = 3;
+    public int getMaxDeckSize() {
+        return maxDeckSize;
+    }
 
-    private DeckRules() {}
+    /**
+     * Check a deck's cards against the rules. Returns a short reason for the
+     * first rule broken, or null when the deck is legal. Preset decks are
+     * exempt from the size range.
+     */
+    public String validate(List<Integer> cardIds, int totalCost, Integer maxCost, boolean preset) {
+        if (maxCost != null && totalCost > maxCost) {
+            return String.format("Total cost %d exceeds budget of %d", totalCost, maxCost);
+        }
+        if (!preset && cardIds.size() < minDeckSize) {
+            return String.format("Deck has %d cards, min is %d", cardIds.size(), minDeckSize);
+        }
+        if (!preset && cardIds.size() > maxDeckSize) {
+            return String.format("Deck has %d cards, max is %d", cardIds.size(), maxDeckSize);
+        }
+        return cardIds.stream()
+            .collect(Collectors.groupingBy(Function.identity(), Collectors.counting()))
+            .entrySet().stream()
+            .filter(entry -> entry.getValue() > maxCopiesPerCard)
+            .min(Map.Entry.comparingByKey())
+            .map(entry -> String.format("Card %d has %d copies, max is %d",
+                entry.getKey(), entry.getValue(), maxCopiesPerCard))
+            .orElse(null);
+    }
 }
@@ -0,0 +1,188 @@
+package com.yugioh.config;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.yugioh.dto.ErrorResponse;
+import com.yugioh.exception.GlobalExceptionHandler;
+import jakarta.servlet.FilterChain;
+import jakarta.servlet.ReadListener;
+import jakarta.servlet.ServletException;
+import jakarta.servlet.ServletInputStream;
+import jakarta.servlet.http.HttpServletRequest;
+import jakarta.servlet.http.HttpServletRequestWrapper;
+import jakarta.servlet.http.HttpServletResponse;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.http.HttpHeaders;
+import org.springframework.http.HttpStatus;
+import org.springframework.http.MediaType;
+import org.springframework.stereotype.Component;
+import org.springframework.web.filter.OncePerRequestFilter;
+
+import java.io.BufferedReader;
+import java.io.ByteArrayInputStream;
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.io.InputStream;
+import java.io.InputStreamReader;
+import java.nio.charset.Charset;
+import java.nio.charset.StandardCharsets;
+import java.time.Clock;
+import java.util.Collections;
+import java.util.Enumeration;
+import java.util.List;
+import java.util.zip.GZIPInputStream;
+
+/**
+ * Transparently decompresses request bodies sent with
+ * {@code Content-Encoding: gzip}, so large batch uploads can be compressed.
+ * The body is inflated up front and capped (MAX_DECOMPRESSED_BODY_BYTES) to
+ * guard against zip bombs; malformed gzip is a 400 and an oversized body a
+ * 413, both with the usual error body.
+ */
+@Component
+public class GzipRequestFilter extends OncePerRequestFilter {
+    private final long maxDecompressedBytes;
+    private final Clock clock;
+    private final ObjectMapper objectMapper;
+
+    public GzipRequestFilter(@Value("${app.request.max-decompressed-bytes}") long maxDecompressedBytes, Clock clock,
+                             ObjectMapper objectMapper) {
+        if (maxDecompressedBytes < 1) {
+            throw new IllegalArgumentException(
+                "app.request.max-decompressed-bytes must be at least 1, got " + maxDecompressedBytes);
+        }
+        this.maxDecompressedBytes = maxDecompressedBytes;
+        this.clock = clock;
+        this.objectMapper = objectMapper;
+    }
+
+    @Override
+    protected boolean shouldNotFilter(HttpServletRequest request) {
+        String encoding = request.getHeader(HttpHeaders.CONTENT_ENCODING);
+        return encoding == null || !encoding.trim().equalsIgnoreCase("gzip");
+    }
+
+    @Override
+    protected void doFilterInternal(HttpServletRequest request, HttpServletResponse response, FilterChain chain)
+            throws ServletException, IOException {
+        byte[] body;
+        try {
+            body = inflate(request.getInputStream());
+        } catch (IOException e) {
+            writeError(response, HttpStatus.BAD_REQUEST, GlobalExceptionHandler.INVALID_BODY,
+                "Request body is not valid gzip");
+            return;
+        }
+        if (body == null) {
+            writeError(response, HttpStatus.PAYLOAD_TOO_LARGE, GlobalExceptionHandler.PAYLOAD_TOO_LARGE,
+                String.format("Decompressed request body exceeds %d bytes", maxDecompressedBytes));
+            return;
+        }
+        chain.doFilter(new DecompressedRequest(request, body), response);
+    }
+
+    /**
+     * Read the whole gzip stream, or return null as soon as it grows past
+     * the limit so a bomb is never fully inflated.
+     */
+    private byte[] inflate(InputStream compressed) throws IOException {
+        try (GZIPInputStream gzip = new GZIPInputStream(compressed)) {
+            ByteArrayOutputStream out = new ByteArrayOutputStream();
+            byte[] buffer = new byte[8192];
+            int read;
+            while ((read = gzip.read(buffer)) != -1) {
+                if (out.size() + read > maxDecompressedBytes) {
+                    return null;
+                }
+                out.write(buffer, 0, read);
+            }
+            return out.toByteArray();
+        }
+    }
+
+    private void writeError(HttpServletResponse response, HttpStatus status, String code, String message)
+            throws IOException {
+        response.setStatus(status.value());
+        response.setContentType(MediaType.APPLICATION_JSON_VALUE);
+        response.setHeader(HttpHeaders.ACCESS_CONTROL_ALLOW_ORIGIN, "*");
+        objectMapper.writeValue(response.getOutputStream(), new ErrorResponse(code, message, clock));
+    }
+
+    /** The original request with the inflated body and no Content-Encoding. */
+    private static class DecompressedRequest extends HttpServletRequestWrapper {
+        private final byte[] body;
+
+        DecompressedRequest(HttpServletRequest request, byte[] body) {
+            super(request);
+            this.body = body;
+        }
+
+        @Override
+        public ServletInputStream getInputStream() {
+            ByteArrayInputStream in = new ByteArrayInputStream(body);
+            return new ServletInputStream() {
+                @Override
+                public int read() {
+                    return in.read();
+                }
+
+                @Override
+                public int read(byte[] b, int off, int len) {
+                    return in.read(b, off, len);
+                }
+
+                @Override
+                public boolean isFinished() {
+                    return in.available() == 0;
+                }
+
+                @Override
+                public boolean isReady() {
+                    return true;
+                }
+
+                @Override
+                public void setReadListener(ReadListener listener) {
+                    throw new UnsupportedOperationException();
+                }
+            };
+        }
+
+        @Override
+        public BufferedReader getReader() {
+            Charset charset = getCharacterEncoding() != null
+                ? Charset.forName(getCharacterEncoding())
+                : StandardCharsets.UTF_8;
+            return new BufferedReader(new InputStreamReader(getInputStream(), charset));
+        }
+
+        @Override
+        public int getContentLength() {
+            return body.length;
+        }
+
+        @Override
+        public long getContentLengthLong() {
+            return body.length;
+        }
+
+        @Override
+        public String getHeader(String name) {
+            if (HttpHeaders.CONTENT_ENCODING.equalsIgnoreCase(name)) {
+                return null;
+            }
+            if (HttpHeaders.CONTENT_LENGTH.equalsIgnoreCase(name)) {
+                return String.valueOf(body.length);
+            }
+            return super.getHeader(name);
+        }
+
+        @Override
+        public Enumeration<String> getHeaders(String name) {
+            String value = getHeader(name);
+            if (HttpHeaders.CONTENT_ENCODING.equalsIgnoreCase(name) || HttpHeaders.CONTENT_LENGTH.equalsIgnoreCase(name)) {
+                return value == null ? Collections.emptyEnumeration() : Collections.enumeration(List.of(value));
+            }
+            return super.getHeaders(name);
+        }
+    }
+}
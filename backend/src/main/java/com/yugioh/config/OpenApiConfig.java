@@ -4,6 +4,7 @@ import io.swagger.v3.oas.models.OpenAPI;
 import io.swagger.v3.oas.models.info.Info;
 import io.swagger.v3.oas.models.info.Contact;
 import io.swagger.v3.oas.models.servers.Server;
+import org.springframework.beans.factory.annotation.Value;
 import org.springframework.context.annotation.Bean;
 import org.springframework.context.annotation.Configuration;
 
@@ -11,8 +12,12 @@ import java.util.List;
 
 @Configuration
 public class OpenApiConfig {
+    /**
+     * The first server is what Swagger UI's "Try it out" targets; it comes
+     * from SWAGGER_SERVER_URL so a deployed UI calls the real API host.
+     */
     @Bean
-    public OpenAPI customOpenAPI() {
+    public OpenAPI customOpenAPI(@Value("${app.swagger.server-url}") String serverUrl) {
         return new OpenAPI()
             .info(new Info()
                 .title("Yu-Gi-Oh! Deck Editor API")
@@ -22,7 +27,7 @@ public class OpenApiConfig {
                     .name("Gustavo Gardusi")
                     .email("gustavo.gardusi@gmail.com")))
             .servers(List.of(
-                new Server().url("http://localhost:8080").description("Local development server"),
+                new Server().url(serverUrl).description("API server"),
                 new Server().url("http://backend:8080").description("Docker container server")
             ));
     }
@@ -0,0 +1,60 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.exception.ValidationException;
+
+import java.util.List;
+import java.util.Objects;
+import java.util.function.Function;
+
+/**
+ * Shared ID handling for batch read endpoints. Each accepts the IDs either as
+ * a comma-separated {@code ids} query parameter (GET, see {@link #parse}) or
+ * as a JSON array body (POST, for lists too long for a URL, see
+ * {@link #parseBody}).
+ */
+public final class BatchIds {
+    /** Maximum number of distinct IDs accepted by one batch request. */
+    public static final int MAX_IDS = 20;
+
+    private BatchIds() {}
+
+    /**
+     * Validate the {@code ids} query parameter and drop duplicates, keeping
+     * request order. A bad list is a 400, like any other query parameter.
+     */
+    public static List<Integer> parse(List<Integer> ids) {
+        return distinct(ids, "Parameter 'ids'", message -> new InvalidParameterException("ids", message));
+    }
+
+    /**
+     * Validate a JSON array body and drop duplicates, keeping request order.
+     * The body itself parsed, so a bad list is a 422 rather than a 400.
+     */
+    public static List<Integer> parseBody(List<Integer> ids) {
+        return distinct(ids, "Body", ValidationException::new);
+    }
+
+    /**
+     * The requested IDs that were not found, in request order.
+     */
+    public static List<Integer> missing(List<Integer> requested, List<Integer> found) {
+        return requested.stream().filter(id -> !found.contains(id)).toList();
+    }
+
+    private static List<Integer> distinct(List<Integer> ids, String subject,
+                                          Function<String, RuntimeException> error) {
+        if (ids == null || ids.isEmpty()) {
+            throw error.apply(subject + " must list at least one ID");
+        }
+        if (ids.stream().anyMatch(Objects::isNull)) {
+            throw error.apply(subject + " must not contain null IDs");
+        }
+
+        List<Integer> distinct = ids.stream().distinct().toList();
+        if (distinct.size() > MAX_IDS) {
+            throw error.apply(String.format("%s accepts at most %d IDs, got %d", subject, MAX_IDS, distinct.size()));
+        }
+        return distinct;
+    }
+}
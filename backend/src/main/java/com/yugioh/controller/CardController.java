@@ -1,8 +1,15 @@
 package com.yugioh.controller;
 
+import com.yugioh.dto.DeckSummary;
 import com.yugioh.dto.PaginationResponse;
+import com.yugioh.dto.PopularCard;
+import com.yugioh.dto.ErrorResponse;
+import com.yugioh.exception.ResourceNotFoundException;
 import com.yugioh.model.Card;
+import com.yugioh.service.CardImageStore;
 import com.yugioh.service.CardService;
+import com.yugioh.service.DeckService;
+import com.fasterxml.jackson.databind.ObjectMapper;
 import io.swagger.v3.oas.annotations.Operation;
 import io.swagger.v3.oas.annotations.Parameter;
 import io.swagger.v3.oas.annotations.media.Content;
@@ -11,28 +18,62 @@ import io.swagger.v3.oas.annotations.responses.ApiResponse;
 import io.swagger.v3.oas.annotations.responses.ApiResponses;
 import io.swagger.v3.oas.annotations.tags.Tag;
 import org.springframework.beans.factory.annotation.Autowired;
+import org.springframework.core.io.FileSystemResource;
+import org.springframework.core.io.Resource;
 import org.springframework.data.domain.Page;
+import org.springframework.http.CacheControl;
+import org.springframework.http.HttpHeaders;
+import org.springframework.http.HttpStatus;
+import org.springframework.http.MediaType;
+import org.springframework.http.MediaTypeFactory;
 import org.springframework.http.ResponseEntity;
 import org.springframework.web.bind.annotation.*;
+import org.springframework.web.context.request.WebRequest;
+import org.springframework.web.servlet.mvc.method.annotation.StreamingResponseBody;
 
+import java.io.IOException;
+import java.io.OutputStream;
+import java.io.UncheckedIOException;
+import java.nio.file.Path;
+import java.time.Duration;
+import java.time.ZoneOffset;
+import java.util.ArrayList;
 import java.util.HashMap;
+import java.util.LinkedHashMap;
 import java.util.List;
 import java.util.Map;
-import java.util.Optional;
+import java.util.Objects;
+import java.util.function.Function;
+import java.util.stream.Collectors;
 
 @RestController
 @RequestMapping("/cards")
 @CrossOrigin(origins = "*")
 @Tag(name = "Cards", description = "API for browsing cards")
 public class CardController {
+    public static final MediaType APPLICATION_NDJSON = MediaType.parseMediaType("application/x-ndjson");
+    private static final byte[] NEWLINE = {'\n'};
+    private static final CacheControl IMAGE_CACHE = CacheControl.maxAge(Duration.ofDays(7)).cachePublic();
+
     @Autowired
     private CardService cardService;
 
+    @Autowired
+    private DeckService deckService;
+
+    @Autowired
+    private CardImageStore cardImageStore;
+
+    @Autowired
+    private ObjectMapper objectMapper;
+
     @GetMapping
     @Operation(summary = "List all cards", description = "Get a paginated list of all cards. Use either 'page' or 'firstCard' query parameter.")
     @ApiResponses(value = {
         @ApiResponse(responseCode = "200", description = "Successful response",
-            content = @Content(schema = @Schema(implementation = Map.class)))
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Non-numeric page or limit, limit out of range, or unknown field",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
     })
     public ResponseEntity<Map<String, Object>> getAllCards(
             @Parameter(description = "Page number (1-based). Ignored if firstCard is provided.", example = "1")
@@ -40,7 +81,18 @@ public class CardController {
             @Parameter(description = "Number of cards per page", example = "24")
             @RequestParam(defaultValue = "24") int limit,
             @Parameter(description = "First card ID to start from. Takes precedence over page.", example = "1")
-            @RequestParam(required = false) Integer firstCard) {
+            @RequestParam(required = false) Integer firstCard,
+            @Parameter(description = "Hide cards already in this deck (unknown deck IDs exclude nothing)", example = "5")
+            @RequestParam(name = "exclude_deck", required = false) Integer excludeDeck,
+            @Parameter(description = "Attach per-type/attribute/rarity counts for the current filters and the unfiltered total", example = "true")
+            @RequestParam(name = "include_facets", defaultValue = "false") boolean includeFacets,
+            @Parameter(description = "Comma-separated card fields to return (default: all)", example = "id,name,image,type")
+            @RequestParam(required = false) List<String> fields,
+            @Parameter(description = "Add first/prev/next/last page URLs to the pagination block (not with firstCard)", example = "true")
+            @RequestParam(name = "include_links", defaultValue = "false") boolean includeLinks) {
+
+        PageParams.checkLimit(limit);
+        List<String> projection = fields == null ? null : CardFields.parse(fields);
 
         // When firstCard is provided, filter from that card and use page 1 of filtered results
         // Otherwise, use the page parameter (default to 1)
@@ -54,8 +106,10 @@ public class CardController {
             calculatedPage = page;
         }
 
-        Page<Card> cardPage = cardService.getAllCards(calculatedPage, limit, startId);
-        List<Card> cards = cardPage.getContent();
+        // A projection is selected in SQL, so only the requested columns are read
+        Page<?> cardPage = projection == null
+            ? cardService.getAllCards(calculatedPage, limit, startId, excludeDeck)
+            : cardService.getAllCardFields(calculatedPage, limit, startId, excludeDeck, projection);
 
         PaginationResponse pagination = new PaginationResponse(
             calculatedPage,
@@ -63,27 +117,359 @@ public class CardController {
             cardPage.getTotalElements(),
             cardPage.getTotalPages()
         );
+        // firstCard filters rather than picks a page, so there is no page to link to
+        if (includeLinks && startId == null) {
+            pagination.setLinks(PageParams.links(calculatedPage, cardPage.getTotalPages()));
+        }
+
+        // Echo the parameters as interpreted, so clients can spot ignored values
+        Map<String, Object> applied = new LinkedHashMap<>();
+        applied.put("page", calculatedPage);
+        applied.put("limit", limit);
+        if (startId != null) {
+            applied.put("firstCard", startId);
+        }
+        if (excludeDeck != null) {
+            applied.put("exclude_deck", excludeDeck);
+        }
+        applied.put("include_facets", includeFacets);
+        if (projection != null) {
+            applied.put("fields", projection);
+        }
+        if (includeLinks && startId == null) {
+            applied.put("include_links", true);
+        }
+        List<String> ignored = new ArrayList<>();
+        if (page != null && (startId != null || page < 1)) {
+            ignored.add("page");
+        }
+        if (firstCard != null && startId == null) {
+            ignored.add("firstCard");
+        }
+        if (includeLinks && startId != null) {
+            ignored.add("include_links");
+        }
+        PageParams.putIgnored(applied, ignored);
 
         Map<String, Object> response = new HashMap<>();
-        response.put("cards", cards);
+        response.put("cards", cardPage.getContent());
         response.put("pagination", pagination);
+        response.put("applied", applied);
+        if (includeFacets) {
+            response.put("facets", cardService.getCardFacets(startId, excludeDeck));
+        }
+
+        return ResponseEntity.ok(response);
+    }
+
+    @GetMapping(value = "/stream", produces = "application/x-ndjson")
+    @Operation(summary = "Stream all cards", description = "Stream every card as newline-delimited JSON, flushing one card per line")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Card stream",
+            content = @Content(mediaType = "application/x-ndjson", schema = @Schema(implementation = Card.class)))
+    })
+    public ResponseEntity<StreamingResponseBody> streamCards() {
+        StreamingResponseBody body = out -> cardService.streamAllCards(card -> writeLine(out, card));
+        return ResponseEntity.ok().contentType(APPLICATION_NDJSON).body(body);
+    }
+
+    private void writeLine(OutputStream out, Card card) {
+        try {
+            out.write(objectMapper.writeValueAsBytes(card));
+            out.write(NEWLINE);
+            out.flush();
+        } catch (IOException e) {
+            // Client went away: abort the stream so the query is closed
+            throw new UncheckedIOException(e);
+        }
+    }
+
+    @GetMapping("/count")
+    @Operation(summary = "Count cards", description = "Get just the number of cards GET /cards would list for the same filters")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Matching card count",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Non-numeric filter value",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> countCards(
+            @Parameter(description = "Count from this card ID onwards", example = "1")
+            @RequestParam(required = false) Integer firstCard,
+            @Parameter(description = "Leave out cards already in this deck (unknown deck IDs exclude nothing)", example = "5")
+            @RequestParam(name = "exclude_deck", required = false) Integer excludeDeck) {
+
+        return ResponseEntity.ok(Map.of("total", cardService.countCards(firstCard, excludeDeck)));
+    }
+
+    @GetMapping("/search")
+    @Operation(summary = "Search cards by text", description = "Paginated, case-insensitive substring match on card names, "
+        + "and optionally descriptions (effect text)")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Matching cards (empty when nothing matches)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Query shorter than " + CardService.MIN_SEARCH_LENGTH
+            + " characters, unknown field, or invalid page or limit",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> searchCards(
+            @Parameter(description = "Text to search for", required = true, example = "destroy")
+            @RequestParam(required = false) String q,
+            @Parameter(description = "Comma-separated fields to match: name, description", example = "name,description")
+            @RequestParam(defaultValue = "name") List<String> fields,
+            @Parameter(description = "Page number (1-based)", example = "1")
+            @RequestParam(required = false) Integer page,
+            @Parameter(description = "Number of cards per page", example = "24")
+            @RequestParam(defaultValue = "24") int limit,
+            @Parameter(description = "Add first/prev/next/last page URLs to the pagination block", example = "true")
+            @RequestParam(name = "include_links", defaultValue = "false") boolean includeLinks) {
+
+        PageParams.checkLimit(limit);
+        int calculatedPage = page != null && page > 0 ? page : 1;
+        List<String> searchFields = fields.stream().map(String::trim).distinct().toList();
+        Page<Card> cardPage = cardService.searchCards(q, searchFields, calculatedPage, limit);
+
+        PaginationResponse pagination = new PaginationResponse(
+            calculatedPage,
+            limit,
+            cardPage.getTotalElements(),
+            cardPage.getTotalPages()
+        );
+        if (includeLinks) {
+            pagination.setLinks(PageParams.links(calculatedPage, cardPage.getTotalPages()));
+        }
+
+        Map<String, Object> applied = new LinkedHashMap<>();
+        applied.put("q", q.trim());
+        applied.put("fields", searchFields);
+        applied.put("page", calculatedPage);
+        applied.put("limit", limit);
+        if (includeLinks) {
+            applied.put("include_links", true);
+        }
+        PageParams.putIgnored(applied, page != null && page < 1 ? List.of("page") : List.of());
+
+        Map<String, Object> response = new HashMap<>();
+        response.put("cards", cardPage.getContent());
+        response.put("pagination", pagination);
+        response.put("applied", applied);
+
+        return ResponseEntity.ok(response);
+    }
+
+    @GetMapping("/popular")
+    @Operation(summary = "List the most-used cards", description = "Paginated list of cards ranked by how many decks include them, "
+        + "each with its deckCount. Cards in no deck are left out.")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Cards, most decks first (ties in ID order)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Non-numeric page or limit, or limit out of range",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> getPopularCards(
+            @Parameter(description = "Page number (1-based)", example = "1")
+            @RequestParam(required = false) Integer page,
+            @Parameter(description = "Number of cards per page", example = "24")
+            @RequestParam(defaultValue = "24") int limit,
+            @Parameter(description = "Only count preset decks (true) or user decks (false); default: all decks", example = "false")
+            @RequestParam(required = false) Boolean preset) {
+
+        PageParams.checkLimit(limit);
+        int calculatedPage = page != null && page > 0 ? page : 1;
+        Page<PopularCard> cardPage = cardService.getPopularCards(calculatedPage, limit, preset);
+
+        PaginationResponse pagination = new PaginationResponse(
+            calculatedPage,
+            limit,
+            cardPage.getTotalElements(),
+            cardPage.getTotalPages()
+        );
+
+        Map<String, Object> applied = new LinkedHashMap<>();
+        applied.put("page", calculatedPage);
+        applied.put("limit", limit);
+        if (preset != null) {
+            applied.put("preset", preset);
+        }
+        PageParams.putIgnored(applied, page != null && page < 1 ? List.of("page") : List.of());
+
+        Map<String, Object> response = new HashMap<>();
+        response.put("cards", cardPage.getContent());
+        response.put("pagination", pagination);
+        response.put("applied", applied);
+
+        return ResponseEntity.ok(response);
+    }
+
+    @GetMapping("/batch")
+    @Operation(summary = "Get several cards by ID", description = "Get up to " + BatchIds.MAX_IDS
+        + " cards in one request, in the order requested. IDs with no card are listed under 'missing'.")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Cards found (missing IDs reported separately)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid, empty or too many card IDs",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> getCardsByIds(
+            @Parameter(description = "Comma-separated card IDs", required = true, example = "1,2,3")
+            @RequestParam List<Integer> ids) {
+        return cardsByIds(BatchIds.parse(ids));
+    }
+
+    @PostMapping("/batch")
+    @Operation(summary = "Get several cards by ID (JSON body)", description = "Same as GET /cards/batch, taking the IDs as a JSON array body")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Cards found (missing IDs reported separately)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Malformed body or a non-integer card ID",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "422", description = "Empty body, null card IDs or too many card IDs",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> postCardsByIds(@RequestBody List<Integer> ids) {
+        return cardsByIds(BatchIds.parseBody(ids));
+    }
+
+    private ResponseEntity<Map<String, Object>> cardsByIds(List<Integer> requested) {
+        Map<Integer, Card> cardsById = cardService.getCardsByIds(requested).stream()
+            .collect(Collectors.toMap(Card::getId, Function.identity()));
+        List<Card> cards = requested.stream().map(cardsById::get).filter(Objects::nonNull).toList();
+
+        Map<String, Object> response = new HashMap<>();
+        response.put("cards", cards);
+        response.put("missing", BatchIds.missing(requested, List.copyOf(cardsById.keySet())));
 
         return ResponseEntity.ok(response);
     }
 
     @GetMapping("/{id}")
-    @Operation(summary = "Get card by ID", description = "Get detailed information about a specific card")
+    @Operation(summary = "Get card by ID", description = "Get detailed information about a specific card. The card's updated_at is sent as Last-Modified, so If-Modified-Since can skip unchanged cards.")
     @ApiResponses(value = {
         @ApiResponse(responseCode = "200", description = "Card found",
             content = @Content(schema = @Schema(implementation = Card.class))),
-        @ApiResponse(responseCode = "404", description = "Card not found")
+        @ApiResponse(responseCode = "304", description = "Card not modified since the client's copy"),
+        @ApiResponse(responseCode = "400", description = "Invalid card ID",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Card not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
     })
     public ResponseEntity<Card> getCardById(
             @Parameter(description = "Card ID", required = true)
-            @PathVariable Integer id) {
+            @PathVariable Integer id,
+            WebRequest request) {
+
+        Card card = cardService.getCardById(id)
+                .orElseThrow(() -> new ResourceNotFoundException("Card", id));
+        if (card.getUpdatedAt() == null) {
+            return ResponseEntity.ok(card);
+        }
+
+        // updated_at is stamped in UTC by the column default and the seed script, and sessions run in UTC
+        long lastModified = card.getUpdatedAt().toInstant(ZoneOffset.UTC).toEpochMilli();
+        // Tomcat only adds Vary when it compresses, so a 304 or a body under the size threshold would lack it
+        if (request.checkNotModified(lastModified)) {
+            return ResponseEntity.status(HttpStatus.NOT_MODIFIED).varyBy(HttpHeaders.ACCEPT_ENCODING).build();
+        }
+        return ResponseEntity.ok().lastModified(lastModified).varyBy(HttpHeaders.ACCEPT_ENCODING).body(card);
+    }
+
+    @GetMapping("/{id}/image")
+    @Operation(summary = "Get card image", description = "Serve the card's image file from the local image directory (CARD_IMAGE_DIR)")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Image file",
+            content = @Content(mediaType = "image/*")),
+        @ApiResponse(responseCode = "304", description = "Image not modified since the client's copy"),
+        @ApiResponse(responseCode = "400", description = "Invalid card ID",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Card or image file not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Resource> getCardImage(
+            @Parameter(description = "Card ID", required = true)
+            @PathVariable Integer id,
+            WebRequest request) {
+
+        // The resolved image is a URL (often the placeholder), so look up the stored file name
+        String image = cardService.getStoredImage(id)
+                .orElseThrow(() -> new ResourceNotFoundException("Card", id));
+        Path file = cardImageStore.find(image)
+                .orElseThrow(() -> new ResourceNotFoundException("Card image", id));
+
+        FileSystemResource resource = new FileSystemResource(file);
+        long lastModified = file.toFile().lastModified();
+        if (request.checkNotModified(lastModified)) {
+            return ResponseEntity.status(HttpStatus.NOT_MODIFIED).cacheControl(IMAGE_CACHE).build();
+        }
+
+        return ResponseEntity.ok()
+            .contentType(MediaTypeFactory.getMediaType(resource).orElse(MediaType.APPLICATION_OCTET_STREAM))
+            .cacheControl(IMAGE_CACHE)
+            .lastModified(lastModified)
+            .body(resource);
+    }
+
+    @GetMapping("/{id}/similar")
+    @Operation(summary = "Get similar cards", description = "Recommend cards with the same type and attribute, ordered by how close their cost, attack and defense are. Spells and traps match by type and cost.")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Similar cards, most similar first",
+            content = @Content(schema = @Schema(implementation = Card.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid card ID or limit",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Card not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<List<Card>> getSimilarCards(
+            @Parameter(description = "Card ID", required = true)
+            @PathVariable Integer id,
+            @Parameter(description = "Number of cards to return (1-" + CardService.MAX_SIMILAR_CARDS + ")", example = "10")
+            @RequestParam(defaultValue = "10") int limit) {
+
+        Card card = cardService.getCardById(id)
+                .orElseThrow(() -> new ResourceNotFoundException("Card", id));
+        return ResponseEntity.ok(cardService.getSimilarCards(card, limit));
+    }
+
+    @GetMapping("/{id}/usage")
+    @Operation(summary = "Get card usage", description = "Get the decks that contain a card and the characters associated with those decks")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Card usage found",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid card ID",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Card not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> getCardUsage(
+            @Parameter(description = "Card ID", required = true)
+            @PathVariable Integer id,
+            @Parameter(description = "Page number (1-based)", example = "1")
+            @RequestParam(required = false) Integer page,
+            @Parameter(description = "Number of decks per page", example = "20")
+            @RequestParam(defaultValue = "20") int limit) {
+
+        PageParams.checkLimit(limit);
+        if (cardService.getCardById(id).isEmpty()) {
+            throw new ResourceNotFoundException("Card", id);
+        }
+
+        int calculatedPage = page != null && page > 0 ? page : 1;
+        Page<DeckSummary> deckPage = deckService.getDecksUsingCard(id, calculatedPage, limit);
+        List<String> characters = deckService.getCharactersUsingCard(id);
+
+        PaginationResponse pagination = new PaginationResponse(
+            calculatedPage,
+            limit,
+            deckPage.getTotalElements(),
+            deckPage.getTotalPages()
+        );
 
-        Optional<Card> card = cardService.getCardById(id);
-        return card.map(ResponseEntity::ok)
-                .orElse(ResponseEntity.notFound().build());
+        Map<String, Object> response = new HashMap<>();
+        response.put("cardId", id);
+        response.put("deckCount", deckPage.getTotalElements());
+        response.put("characterCount", characters.size());
+        response.put("characters", characters);
+        response.put("decks", deckPage.getContent());
+        response.put("pagination", pagination);
+
+        return ResponseEntity.ok(response);
     }
 }
@@ -0,0 +1,37 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.InvalidParameterException;
+
+import java.util.List;
+
+/**
+ * Field names for the card list projection ({@code fields=id,name,image,type}),
+ * so grid views can skip the fields they do not render. Names match both the
+ * card's JSON properties and its entity attributes, so they can be selected
+ * directly in SQL.
+ */
+public final class CardFields {
+    /** Every field that can be requested, in JSON order. */
+    public static final List<String> ALL = List.of(
+        "id", "name", "description", "image", "type", "attribute", "race", "level",
+        "attackPoints", "defensePoints", "cost", "rarity", "createdAt", "updatedAt");
+
+    private CardFields() {}
+
+    /**
+     * Validate requested field names, trimming them and dropping duplicates.
+     */
+    public static List<String> parse(List<String> fields) {
+        List<String> requested = fields.stream().map(String::trim).filter(f -> !f.isEmpty()).distinct().toList();
+        if (requested.isEmpty()) {
+            throw new InvalidParameterException("fields", "Parameter 'fields' must list at least one field");
+        }
+        for (String field : requested) {
+            if (!ALL.contains(field)) {
+                throw new InvalidParameterException("fields",
+                    String.format("Parameter 'fields' must only contain %s, got '%s'", ALL, field));
+            }
+        }
+        return requested;
+    }
+}
@@ -1,8 +1,13 @@
 package com.yugioh.controller;
 
+import com.yugioh.config.DeckCloneLimit;
 import com.yugioh.dto.DeckSummary;
+import com.yugioh.dto.DeckUpdateRequest;
 import com.yugioh.dto.DeckWithCards;
+import com.yugioh.dto.ErrorResponse;
 import com.yugioh.dto.PaginationResponse;
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.exception.ResourceNotFoundException;
 import com.yugioh.service.DeckService;
 import io.swagger.v3.oas.annotations.Operation;
 import io.swagger.v3.oas.annotations.Parameter;
@@ -16,9 +21,12 @@ import org.springframework.data.domain.Page;
 import org.springframework.http.ResponseEntity;
 import org.springframework.web.bind.annotation.*;
 
+import java.net.URI;
+import java.util.ArrayList;
 import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
 import java.util.Map;
-import java.util.Optional;
 
 @RestController
 @RequestMapping("/decks")
@@ -28,36 +36,156 @@ public class DeckController {
     @Autowired
     private DeckService deckService;
 
+    @Autowired
+    private DeckCloneLimit deckCloneLimit;
+
     @GetMapping
     @Operation(summary = "List all decks", description = "Get a paginated list of all decks. Use either 'page' or 'firstDeck' query parameter.")
     @ApiResponses(value = {
         @ApiResponse(responseCode = "200", description = "Successful response",
-            content = @Content(schema = @Schema(implementation = Map.class)))
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Unsupported sort key, min_cost above max_cost, non-numeric page or limit, or limit out of range",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
     })
     public ResponseEntity<Map<String, Object>> getAllDecks(
             @Parameter(description = "Page number (1-based). Ignored if firstDeck is provided.", example = "1")
             @RequestParam(required = false) Integer page,
             @Parameter(description = "Number of decks per page", example = "20")
             @RequestParam(defaultValue = "20") int limit,
-            @Parameter(description = "First deck ID to start from. Takes precedence over page.", example = "1")
+            @Parameter(description = "First deck ID to start from. Takes precedence over page; ignored when sort is set.", example = "1")
             @RequestParam(required = false) Integer firstDeck,
             @Parameter(description = "Filter by deck archetype")
             @RequestParam(required = false) String archetype,
             @Parameter(description = "Filter preset decks")
-            @RequestParam(required = false) Boolean preset) {
+            @RequestParam(required = false) Boolean preset,
+            @Parameter(description = "Order by total card cost ('cost') or total attack plus defense ('power'); prefix with '-' for descending", example = "-power")
+            @RequestParam(required = false) String sort,
+            @Parameter(description = "Only decks whose total card cost is at least this", example = "20")
+            @RequestParam(name = "min_cost", required = false) Integer minCost,
+            @Parameter(description = "Only decks whose total card cost is at most this", example = "80")
+            @RequestParam(name = "max_cost", required = false) Integer maxCost,
+            @Parameter(description = "Add first/prev/next/last page URLs to the pagination block", example = "true")
+            @RequestParam(name = "include_links", defaultValue = "false") boolean includeLinks) {
+
+        PageParams.checkLimit(limit);
+
+        // firstDeck is located in id order, so it cannot pick a page of a sorted list
+        boolean sorted = sort != null && !sort.isBlank();
+        boolean useFirstDeck = firstDeck != null && firstDeck > 0 && !sorted;
 
         // Calculate page from firstDeck if provided, otherwise use page (default to 1)
         int calculatedPage = 1;
-        if (firstDeck != null && firstDeck > 0) {
+        if (useFirstDeck) {
             // Calculate which page this deck would be on
             // We need to find the position of the deck in the filtered results
-            calculatedPage = deckService.calculatePageFromDeckId(firstDeck, limit, archetype, preset != null && preset);
+            calculatedPage = deckService.calculatePageFromDeckId(firstDeck, limit, archetype, preset != null && preset,
+                minCost, maxCost);
         } else if (page != null && page > 0) {
             calculatedPage = page;
         }
 
         Boolean presetOnly = preset != null && preset ? true : null;
-        Page<DeckSummary> deckPage = deckService.getAllDecks(calculatedPage, limit, archetype, presetOnly);
+        Page<DeckSummary> deckPage = deckService.getAllDecks(calculatedPage, limit, archetype, presetOnly, sort, minCost, maxCost);
+
+        PaginationResponse pagination = new PaginationResponse(
+            calculatedPage,
+            limit,
+            deckPage.getTotalElements(),
+            deckPage.getTotalPages()
+        );
+        if (includeLinks) {
+            // firstDeck only picks the page, so the links use page instead
+            pagination.setLinks(PageParams.links(calculatedPage, deckPage.getTotalPages(), "firstDeck"));
+        }
+
+        // Echo the parameters as interpreted, so clients can spot ignored values
+        Map<String, Object> applied = new LinkedHashMap<>();
+        applied.put("page", calculatedPage);
+        applied.put("limit", limit);
+        if (useFirstDeck) {
+            applied.put("firstDeck", firstDeck);
+        }
+        if (archetype != null) {
+            applied.put("archetype", archetype);
+        }
+        if (presetOnly != null) {
+            applied.put("preset", presetOnly);
+        }
+        if (sorted) {
+            applied.put("sort", sort.trim());
+        }
+        if (minCost != null) {
+            applied.put("min_cost", minCost);
+        }
+        if (maxCost != null) {
+            applied.put("max_cost", maxCost);
+        }
+        if (includeLinks) {
+            applied.put("include_links", true);
+        }
+        List<String> ignored = new ArrayList<>();
+        if (page != null && (useFirstDeck || page < 1)) {
+            ignored.add("page");
+        }
+        if (firstDeck != null && !useFirstDeck) {
+            ignored.add("firstDeck");
+        }
+        if (preset != null && presetOnly == null) {
+            ignored.add("preset");
+        }
+        PageParams.putIgnored(applied, ignored);
+
+        Map<String, Object> response = new HashMap<>();
+        response.put("decks", deckPage.getContent());
+        response.put("pagination", pagination);
+        response.put("applied", applied);
+
+        return ResponseEntity.ok(response);
+    }
+
+    @GetMapping("/count")
+    @Operation(summary = "Count decks", description = "Get just the number of decks GET /decks would list for the same filters")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Matching deck count",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid filter value",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> countDecks(
+            @Parameter(description = "Filter by deck archetype")
+            @RequestParam(required = false) String archetype,
+            @Parameter(description = "Filter preset decks")
+            @RequestParam(required = false) Boolean preset,
+            @Parameter(description = "Only decks whose total card cost is at least this", example = "20")
+            @RequestParam(name = "min_cost", required = false) Integer minCost,
+            @Parameter(description = "Only decks whose total card cost is at most this", example = "80")
+            @RequestParam(name = "max_cost", required = false) Integer maxCost) {
+
+        Boolean presetOnly = preset != null && preset ? true : null;
+        return ResponseEntity.ok(Map.of("total", deckService.countDecks(archetype, presetOnly, minCost, maxCost)));
+    }
+
+    @GetMapping("/search")
+    @Operation(summary = "Search decks by name", description = "Paginated, case-insensitive substring match on deck names")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Matching decks (empty when nothing matches)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Non-numeric page or limit, or limit out of range",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> searchDecks(
+            @Parameter(description = "Text to search for in deck names", example = "Yugi")
+            @RequestParam(required = false) String q,
+            @Parameter(description = "Page number (1-based)", example = "1")
+            @RequestParam(required = false) Integer page,
+            @Parameter(description = "Number of decks per page", example = "20")
+            @RequestParam(defaultValue = "20") int limit,
+            @Parameter(description = "Add first/prev/next/last page URLs to the pagination block", example = "true")
+            @RequestParam(name = "include_links", defaultValue = "false") boolean includeLinks) {
+
+        PageParams.checkLimit(limit);
+        int calculatedPage = page != null && page > 0 ? page : 1;
+        Page<DeckSummary> deckPage = deckService.searchDecksByName(q, calculatedPage, limit);
 
         PaginationResponse pagination = new PaginationResponse(
             calculatedPage,
@@ -65,10 +193,62 @@ public class DeckController {
             deckPage.getTotalElements(),
             deckPage.getTotalPages()
         );
+        if (includeLinks) {
+            pagination.setLinks(PageParams.links(calculatedPage, deckPage.getTotalPages()));
+        }
+
+        Map<String, Object> applied = new LinkedHashMap<>();
+        applied.put("q", q == null ? null : q.trim());
+        applied.put("page", calculatedPage);
+        applied.put("limit", limit);
+        if (includeLinks) {
+            applied.put("include_links", true);
+        }
+        PageParams.putIgnored(applied, page != null && page < 1 ? List.of("page") : List.of());
 
         Map<String, Object> response = new HashMap<>();
         response.put("decks", deckPage.getContent());
         response.put("pagination", pagination);
+        response.put("applied", applied);
+
+        return ResponseEntity.ok(response);
+    }
+
+    @GetMapping("/batch")
+    @Operation(summary = "Get several decks by ID", description = "Get up to " + BatchIds.MAX_IDS
+        + " decks with all cards in one request, in the order requested. IDs with no deck are listed under 'missing'.")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Decks found (missing IDs reported separately)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid, empty or too many deck IDs",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> getDecksByIds(
+            @Parameter(description = "Comma-separated deck IDs", required = true, example = "1,2,3")
+            @RequestParam List<Integer> ids) {
+        return decksByIds(BatchIds.parse(ids));
+    }
+
+    @PostMapping("/batch")
+    @Operation(summary = "Get several decks by ID (JSON body)", description = "Same as GET /decks/batch, taking the IDs as a JSON array body")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Decks found (missing IDs reported separately)",
+            content = @Content(schema = @Schema(implementation = Map.class))),
+        @ApiResponse(responseCode = "400", description = "Malformed body or a non-integer deck ID",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "422", description = "Empty body, null deck IDs or too many deck IDs",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<Map<String, Object>> postDecksByIds(@RequestBody List<Integer> ids) {
+        return decksByIds(BatchIds.parseBody(ids));
+    }
+
+    private ResponseEntity<Map<String, Object>> decksByIds(List<Integer> requested) {
+        List<DeckWithCards> decks = deckService.getDecksByIds(requested);
+
+        Map<String, Object> response = new HashMap<>();
+        response.put("decks", decks);
+        response.put("missing", BatchIds.missing(requested, decks.stream().map(DeckWithCards::getId).toList()));
 
         return ResponseEntity.ok(response);
     }
@@ -78,14 +258,115 @@ public class DeckController {
     @ApiResponses(value = {
         @ApiResponse(responseCode = "200", description = "Deck found",
             content = @Content(schema = @Schema(implementation = DeckWithCards.class))),
-        @ApiResponse(responseCode = "404", description = "Deck not found")
+        @ApiResponse(responseCode = "400", description = "Invalid deck ID",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Deck not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
     })
     public ResponseEntity<DeckWithCards> getDeckById(
             @Parameter(description = "Deck ID", required = true)
             @PathVariable Integer id) {
 
-        Optional<DeckWithCards> deck = deckService.getDeckById(id);
-        return deck.map(ResponseEntity::ok)
-                .orElse(ResponseEntity.notFound().build());
+        DeckWithCards deck = deckService.getDeckById(id)
+                .orElseThrow(() -> new ResourceNotFoundException("Deck", id));
+        return ResponseEntity.ok(deck);
+    }
+
+    @GetMapping("/{id}/cards")
+    @Operation(summary = "Get a deck's cards", description = "Get a deck's composition in position order: "
+        + "just the card IDs (detail=ids, default) or the full cards (detail=full)")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Card IDs or cards, one entry per copy",
+            content = @Content(schema = @Schema(implementation = List.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid deck ID or unsupported detail value",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Deck not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<List<?>> getDeckCards(
+            @Parameter(description = "Deck ID", required = true)
+            @PathVariable Integer id,
+            @Parameter(description = "'ids' for card IDs only, 'full' for complete cards", example = "ids")
+            @RequestParam(defaultValue = "ids") String detail) {
+
+        List<?> cards = switch (detail.trim()) {
+            case "ids" -> deckService.getDeckCardIds(id)
+                .orElseThrow(() -> new ResourceNotFoundException("Deck", id));
+            case "full" -> deckService.getDeckById(id)
+                .map(DeckWithCards::getCards)
+                .orElseThrow(() -> new ResourceNotFoundException("Deck", id));
+            default -> throw new InvalidParameterException("detail",
+                String.format("Parameter 'detail' must be one of [ids, full], got '%s'", detail));
+        };
+        return ResponseEntity.ok(cards);
+    }
+
+    @GetMapping("/by-name/{name}")
+    @Operation(summary = "Get deck by name", description = "Get a deck with all cards by case-insensitive exact name, for shareable links")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Deck found",
+            content = @Content(schema = @Schema(implementation = DeckWithCards.class))),
+        @ApiResponse(responseCode = "404", description = "No deck has this name",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "409", description = "The name matches more than one deck",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<DeckWithCards> getDeckByName(
+            @Parameter(description = "Deck name", required = true, example = "Yugi's Deck")
+            @PathVariable String name) {
+
+        DeckWithCards deck = deckService.getDeckByName(name)
+                .orElseThrow(() -> new ResourceNotFoundException("Deck", name));
+        return ResponseEntity.ok(deck);
+    }
+
+    @PatchMapping("/{id}")
+    @Operation(summary = "Update a deck", description = "Change any of name, description, archetype and maxCost "
+        + "without touching the deck's cards. Fields left out keep their current value.")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "200", description = "Deck updated",
+            content = @Content(schema = @Schema(implementation = DeckWithCards.class))),
+        @ApiResponse(responseCode = "400", description = "Invalid deck ID or malformed body",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "403", description = "Preset decks cannot be modified",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Deck not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "409", description = "Another deck already has the new name",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "422", description = "Blank name, negative max cost, or max cost below the deck's total cost",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<DeckWithCards> updateDeck(
+            @Parameter(description = "Deck ID", required = true)
+            @PathVariable Integer id,
+            @RequestBody DeckUpdateRequest update) {
+
+        DeckWithCards deck = deckService.updateDeck(id, update)
+                .orElseThrow(() -> new ResourceNotFoundException("Deck", id));
+        return ResponseEntity.ok(deck);
+    }
+
+    @PostMapping("/{id}/clone")
+    @Operation(summary = "Clone a deck", description = "Copy a deck and its cards into a new editable (non-preset) deck. "
+        + "Disabled unless DECK_CLONE_ENABLED is set, and limited to DECK_CLONES_PER_MINUTE clones per minute.")
+    @ApiResponses(value = {
+        @ApiResponse(responseCode = "201", description = "Deck cloned",
+            content = @Content(schema = @Schema(implementation = DeckWithCards.class))),
+        @ApiResponse(responseCode = "403", description = "Deck cloning is disabled on this server",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "404", description = "Source deck not found",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class))),
+        @ApiResponse(responseCode = "429", description = "Too many clones in the last minute",
+            content = @Content(schema = @Schema(implementation = ErrorResponse.class)))
+    })
+    public ResponseEntity<DeckWithCards> cloneDeck(
+            @Parameter(description = "Source deck ID", required = true)
+            @PathVariable Integer id) {
+
+        deckCloneLimit.acquire();
+        DeckWithCards clone = deckService.cloneDeck(id)
+                .orElseThrow(() -> new ResourceNotFoundException("Deck", id));
+        return ResponseEntity.created(URI.create("/decks/" + clone.getId())).body(clone);
     }
 }
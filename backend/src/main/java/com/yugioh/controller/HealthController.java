@@ -1,20 +1,48 @@
 package com.yugioh.controller;
 
+import org.springframework.beans.factory.annotation.Value;
 import org.springframework.http.ResponseEntity;
 import org.springframework.web.bind.annotation.CrossOrigin;
 import org.springframework.web.bind.annotation.GetMapping;
 import org.springframework.web.bind.annotation.RestController;
 
+import java.time.Clock;
+import java.time.format.DateTimeFormatter;
 import java.util.HashMap;
 import java.util.Map;
 
 @RestController
 @CrossOrigin(origins = "*")
 public class HealthController {
+    private final Clock clock;
+
+    @Value("${app.build.version}")
+    private String buildVersion;
+
+    @Value("${app.build.commit}")
+    private String buildCommit;
+
+    @Value("${app.build.time}")
+    private String buildTime;
+
+    public HealthController(Clock clock) {
+        this.clock = clock;
+    }
+
     @GetMapping("/healthcheck")
     public ResponseEntity<Map<String, String>> healthCheck() {
         Map<String, String> response = new HashMap<>();
         response.put("status", "healthy");
+        response.put("timestamp", DateTimeFormatter.ISO_INSTANT.format(clock.instant()));
+        return ResponseEntity.ok(response);
+    }
+
+    @GetMapping("/version")
+    public ResponseEntity<Map<String, String>> version() {
+        Map<String, String> response = new HashMap<>();
+        response.put("version", buildVersion);
+        response.put("commit", buildCommit);
+        response.put("buildTime", buildTime);
         return ResponseEntity.ok(response);
     }
 }
@@ -0,0 +1,69 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.InvalidParameterException;
+import org.springframework.web.servlet.support.ServletUriComponentsBuilder;
+import org.springframework.web.util.UriComponentsBuilder;
+
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * Shared validation for paginated list endpoints. Non-numeric page/limit
+ * values are rejected by Spring's type conversion (invalid_parameter); an
+ * out-of-range limit is rejected here, while a page that is overridden or
+ * out of range falls back to the default and is reported under
+ * {@code applied.ignored}.
+ */
+public final class PageParams {
+    /** Maximum page size for list endpoints. */
+    public static final int MAX_LIMIT = 100;
+
+    private PageParams() {}
+
+    public static int checkLimit(int limit) {
+        if (limit < 1 || limit > MAX_LIMIT) {
+            throw new InvalidParameterException("limit",
+                String.format("Parameter 'limit' must be between 1 and %d, got %d", MAX_LIMIT, limit));
+        }
+        return limit;
+    }
+
+    /**
+     * Add the names of parameters the client sent but that had no effect.
+     */
+    public static void putIgnored(Map<String, Object> applied, List<String> ignored) {
+        if (!ignored.isEmpty()) {
+            applied.put("ignored", ignored);
+        }
+    }
+
+    /**
+     * Build first/prev/next/last URLs from the current request, keeping its
+     * query parameters but setting {@code page} and dropping the given
+     * parameters that would override it. prev and next are left out on the
+     * first and last page.
+     */
+    public static Map<String, String> links(int page, int totalPages, String... overriding) {
+        UriComponentsBuilder base = ServletUriComponentsBuilder.fromCurrentRequest();
+        for (String name : overriding) {
+            base.replaceQueryParam(name);
+        }
+        int last = Math.max(totalPages, 1);
+
+        Map<String, String> links = new LinkedHashMap<>();
+        links.put("first", pageUrl(base, 1));
+        if (page > 1) {
+            links.put("prev", pageUrl(base, Math.min(page - 1, last)));
+        }
+        if (page < last) {
+            links.put("next", pageUrl(base, page + 1));
+        }
+        links.put("last", pageUrl(base, last));
+        return links;
+    }
+
+    private static String pageUrl(UriComponentsBuilder base, int page) {
+        return base.cloneBuilder().replaceQueryParam("page", page).build().toUriString();
+    }
+}
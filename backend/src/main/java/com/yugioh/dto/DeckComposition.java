@@ -0,0 +1,54 @@
+package com.yugioh.dto;
+
+/**
+ * Card type breakdown of a deck, counting every copy. monsterRatio is the
+ * share of monsters among all cards (0 to 1, two decimals).
+ */
+public class DeckComposition {
+    private Integer monsters;
+    private Integer spells;
+    private Integer traps;
+    private Double monsterRatio;
+
+    public DeckComposition() {}
+
+    public DeckComposition(Integer monsters, Integer spells, Integer traps, Double monsterRatio) {
+        this.monsters = monsters;
+        this.spells = spells;
+        this.traps = traps;
+        this.monsterRatio = monsterRatio;
+    }
+
+    // Getters and Setters
+    public Integer getMonsters() {
+        return monsters;
+    }
+
+    public void setMonsters(Integer monsters) {
+        this.monsters = monsters;
+    }
+
+    public Integer getSpells() {
+        return spells;
+    }
+
+    public void setSpells(Integer spells) {
+        this.spells = spells;
+    }
+
+    public Integer getTraps() {
+        return traps;
+    }
+
+    public void setTraps(Integer traps) {
+        this.traps = traps;
+    }
+
+    public Double getMonsterRatio() {
+        return monsterRatio;
+    }
+
+    public void setMonsterRatio(Double monsterRatio) {
+        this.monsterRatio = monsterRatio;
+    }
+}
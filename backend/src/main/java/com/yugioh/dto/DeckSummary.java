@@ -11,6 +11,8 @@ public class DeckSummary {
     private Integer totalCost;
     private Integer cardCount;
     private Boolean isPreset;
+    private Boolean isLegal;
+    private String legalityReason;
 
     public DeckSummary() {}
 
@@ -109,4 +111,20 @@ public class DeckSummary {
     public void setIsPreset(Boolean isPreset) {
         this.isPreset = isPreset;
     }
+
+    public Boolean getIsLegal() {
+        return isLegal;
+    }
+
+    public void setIsLegal(Boolean isLegal) {
+        this.isLegal = isLegal;
+    }
+
+    public String getLegalityReason() {
+        return legalityReason;
+    }
+
+    public void setLegalityReason(String legalityReason) {
+        this.legalityReason = legalityReason;
+    }
 }
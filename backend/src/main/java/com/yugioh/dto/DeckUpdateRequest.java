@@ -0,0 +1,47 @@
+package com.yugioh.dto;
+
+/**
+ * Body of a partial deck update. Fields left out (null) keep their current
+ * value; the deck's cards are never touched.
+ */
+public class DeckUpdateRequest {
+    private String name;
+    private String description;
+    private String archetype;
+    private Integer maxCost;
+
+    public DeckUpdateRequest() {}
+
+    // Getters and Setters
+    public String getName() {
+        return name;
+    }
+
+    public void setName(String name) {
+        this.name = name;
+    }
+
+    public String getDescription() {
+        return description;
+    }
+
+    public void setDescription(String description) {
+        this.description = description;
+    }
+
+    public String getArchetype() {
+        return archetype;
+    }
+
+    public void setArchetype(String archetype) {
+        this.archetype = archetype;
+    }
+
+    public Integer getMaxCost() {
+        return maxCost;
+    }
+
+    public void setMaxCost(Integer maxCost) {
+        this.maxCost = maxCost;
+    }
+}
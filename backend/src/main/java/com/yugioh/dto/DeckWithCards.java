@@ -10,10 +10,11 @@ public class DeckWithCards {
     private String characterName;
     private String archetype;
     private String mostCommonType;
-    private List<Card> cards;
+    private List<Card> cards = List.of();
     private Integer maxCost;
     private Integer totalCost;
     private Boolean isPreset;
+    private DeckComposition composition;
 
     public DeckWithCards() {}
 
@@ -70,8 +71,9 @@ public class DeckWithCards {
         return cards;
     }
 
+    /** A null list is stored as empty, so the deck always serializes "cards" as an array. */
     public void setCards(List<Card> cards) {
-        this.cards = cards;
+        this.cards = cards == null ? List.of() : cards;
     }
 
     public Integer getMaxCost() {
@@ -97,4 +99,12 @@ public class DeckWithCards {
     public void setIsPreset(Boolean isPreset) {
         this.isPreset = isPreset;
     }
+
+    public DeckComposition getComposition() {
+        return composition;
+    }
+
+    public void setComposition(DeckComposition composition) {
+        this.composition = composition;
+    }
 }
@@ -0,0 +1,38 @@
+package com.yugioh.dto;
+
+import java.time.Clock;
+import java.time.format.DateTimeFormatter;
+
+public class ErrorResponse {
+    private ApiError error;
+    private String timestamp;
+
+    public ErrorResponse() {}
+
+    public ErrorResponse(String code, String message) {
+        this.error = new ApiError(code, message);
+    }
+
+    /** An error stamped with the clock's current instant (RFC 3339, UTC). */
+    public ErrorResponse(String code, String message, Clock clock) {
+        this(code, message);
+        this.timestamp = DateTimeFormatter.ISO_INSTANT.format(clock.instant());
+    }
+
+    // Getters and Setters
+    public ApiError getError() {
+        return error;
+    }
+
+    public void setError(ApiError error) {
+        this.error = error;
+    }
+
+    public String getTimestamp() {
+        return timestamp;
+    }
+
+    public void setTimestamp(String timestamp) {
+        this.timestamp = timestamp;
+    }
+}
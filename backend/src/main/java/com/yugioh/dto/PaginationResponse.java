@@ -1,11 +1,19 @@
 package com.yugioh.dto;
 
+import com.fasterxml.jackson.annotation.JsonInclude;
+
+import java.util.Map;
+
 public class PaginationResponse {
     private Integer page;
     private Integer limit;
     private Long total;
     private Integer totalPages;
 
+    /** first/prev/next/last page URLs, only present when requested. */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    private Map<String, String> links;
+
     public PaginationResponse() {}
 
     public PaginationResponse(Integer page, Integer limit, Long total, Integer totalPages) {
@@ -47,4 +55,12 @@ public class PaginationResponse {
     public void setTotalPages(Integer totalPages) {
         this.totalPages = totalPages;
     }
+
+    public Map<String, String> getLinks() {
+        return links;
+    }
+
+    public void setLinks(Map<String, String> links) {
+        this.links = links;
+    }
 }
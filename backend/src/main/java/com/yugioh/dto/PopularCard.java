@@ -0,0 +1,38 @@
+package com.yugioh.dto;
+
+import com.fasterxml.jackson.annotation.JsonUnwrapped;
+import com.yugioh.model.Card;
+
+/**
+ * A card with the number of distinct decks that include it. The card's
+ * fields are inlined, so it reads like a card with an extra deckCount.
+ */
+public class PopularCard {
+    @JsonUnwrapped
+    private Card card;
+    private Long deckCount;
+
+    public PopularCard() {}
+
+    public PopularCard(Card card, Long deckCount) {
+        this.card = card;
+        this.deckCount = deckCount;
+    }
+
+    // Getters and Setters
+    public Card getCard() {
+        return card;
+    }
+
+    public void setCard(Card card) {
+        this.card = card;
+    }
+
+    public Long getDeckCount() {
+        return deckCount;
+    }
+
+    public void setDeckCount(Long deckCount) {
+        this.deckCount = deckCount;
+    }
+}
@@ -0,0 +1,11 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when a request cannot be resolved to a single resource, e.g. an
+ * ambiguous deck name.
+ */
+public class ConflictException extends RuntimeException {
+    public ConflictException(String message) {
+        super(message);
+    }
+}
@@ -0,0 +1,10 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when the database cannot be reached, even after a retry.
+ */
+public class DatabaseUnavailableException extends RuntimeException {
+    public DatabaseUnavailableException(String message, Throwable cause) {
+        super(message, cause);
+    }
+}
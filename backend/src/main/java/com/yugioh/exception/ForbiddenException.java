@@ -0,0 +1,10 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when a resource exists but may not be modified, e.g. a preset deck.
+ */
+public class ForbiddenException extends RuntimeException {
+    public ForbiddenException(String message) {
+        super(message);
+    }
+}
@@ -0,0 +1,171 @@
+package com.yugioh.exception;
+
+import com.fasterxml.jackson.core.JsonProcessingException;
+import com.fasterxml.jackson.databind.JsonMappingException;
+import com.fasterxml.jackson.databind.exc.MismatchedInputException;
+import com.fasterxml.jackson.databind.exc.UnrecognizedPropertyException;
+import com.yugioh.dto.ErrorResponse;
+import jakarta.servlet.http.HttpServletRequest;
+import org.springframework.http.HttpHeaders;
+import org.springframework.http.HttpStatus;
+import org.springframework.http.ResponseEntity;
+import org.springframework.http.converter.HttpMessageNotReadableException;
+import org.springframework.web.bind.annotation.ExceptionHandler;
+import org.springframework.web.bind.annotation.RestControllerAdvice;
+import org.springframework.web.method.annotation.MethodArgumentTypeMismatchException;
+import org.springframework.web.servlet.NoHandlerFoundException;
+import org.springframework.web.servlet.resource.NoResourceFoundException;
+
+import java.time.Clock;
+import java.util.Collection;
+import java.util.stream.Collectors;
+
+/**
+ * Maps exceptions to structured error bodies with a machine-readable code,
+ * so clients can tell a malformed request apart from a missing resource.
+ * Every body carries a server-side timestamp to correlate with the logs.
+ */
+@RestControllerAdvice
+public class GlobalExceptionHandler {
+    public static final String INVALID_ID = "invalid_id";
+    public static final String INVALID_PARAMETER = "invalid_parameter";
+    public static final String INVALID_BODY = "invalid_body";
+    public static final String VALIDATION_FAILED = "validation_failed";
+    public static final String FORBIDDEN = "forbidden";
+    public static final String NOT_FOUND = "not_found";
+    public static final String CONFLICT = "conflict";
+    public static final String PAYLOAD_TOO_LARGE = "payload_too_large";
+    public static final String RATE_LIMITED = "rate_limited";
+    public static final String SERVICE_UNAVAILABLE = "service_unavailable";
+
+    private final Clock clock;
+
+    public GlobalExceptionHandler(Clock clock) {
+        this.clock = clock;
+    }
+
+    @ExceptionHandler(MethodArgumentTypeMismatchException.class)
+    public ResponseEntity<ErrorResponse> handleTypeMismatch(MethodArgumentTypeMismatchException ex) {
+        String code = "id".equals(ex.getName()) ? INVALID_ID : INVALID_PARAMETER;
+        String type = ex.getRequiredType() != null ? ex.getRequiredType().getSimpleName() : "value";
+        String message = String.format("Parameter '%s' must be a valid %s, got '%s'", ex.getName(), type, ex.getValue());
+        return ResponseEntity.status(HttpStatus.BAD_REQUEST).body(error(code, message));
+    }
+
+    @ExceptionHandler(InvalidParameterException.class)
+    public ResponseEntity<ErrorResponse> handleInvalidParameter(InvalidParameterException ex) {
+        return ResponseEntity.status(HttpStatus.BAD_REQUEST).body(error(INVALID_PARAMETER, ex.getMessage()));
+    }
+
+    @ExceptionHandler(ValidationException.class)
+    public ResponseEntity<ErrorResponse> handleValidation(ValidationException ex) {
+        return ResponseEntity.status(HttpStatus.UNPROCESSABLE_ENTITY)
+            .body(error(VALIDATION_FAILED, ex.getMessage()));
+    }
+
+    @ExceptionHandler(ForbiddenException.class)
+    public ResponseEntity<ErrorResponse> handleForbidden(ForbiddenException ex) {
+        return ResponseEntity.status(HttpStatus.FORBIDDEN).body(error(FORBIDDEN, ex.getMessage()));
+    }
+
+    @ExceptionHandler(ResourceNotFoundException.class)
+    public ResponseEntity<ErrorResponse> handleNotFound(ResourceNotFoundException ex) {
+        return ResponseEntity.status(HttpStatus.NOT_FOUND).body(error(NOT_FOUND, ex.getMessage()));
+    }
+
+    /**
+     * Unmatched paths never reach a controller, so they miss @CrossOrigin;
+     * the origin header is added here so browsers can read the 404 body.
+     */
+    @ExceptionHandler({NoHandlerFoundException.class, NoResourceFoundException.class})
+    public ResponseEntity<ErrorResponse> handleUnknownRoute(HttpServletRequest request) {
+        String message = String.format("No route for %s %s", request.getMethod(), request.getRequestURI());
+        return ResponseEntity.status(HttpStatus.NOT_FOUND)
+            .header(HttpHeaders.ACCESS_CONTROL_ALLOW_ORIGIN, "*")
+            .body(error(NOT_FOUND, message));
+    }
+
+    @ExceptionHandler(ConflictException.class)
+    public ResponseEntity<ErrorResponse> handleConflict(ConflictException ex) {
+        return ResponseEntity.status(HttpStatus.CONFLICT).body(error(CONFLICT, ex.getMessage()));
+    }
+
+    @ExceptionHandler(RateLimitedException.class)
+    public ResponseEntity<ErrorResponse> handleRateLimited(RateLimitedException ex) {
+        return ResponseEntity.status(HttpStatus.TOO_MANY_REQUESTS).body(error(RATE_LIMITED, ex.getMessage()));
+    }
+
+    @ExceptionHandler(DatabaseUnavailableException.class)
+    public ResponseEntity<ErrorResponse> handleDatabaseUnavailable(DatabaseUnavailableException ex) {
+        return ResponseEntity.status(HttpStatus.SERVICE_UNAVAILABLE)
+            .body(error(SERVICE_UNAVAILABLE, ex.getMessage()));
+    }
+
+    @ExceptionHandler(HttpMessageNotReadableException.class)
+    public ResponseEntity<ErrorResponse> handleUnreadableBody(HttpMessageNotReadableException ex) {
+        String message = isMissingBody(ex) ? "Request body is required" : describeBodyError(ex.getCause());
+        return ResponseEntity.status(HttpStatus.BAD_REQUEST).body(error(INVALID_BODY, message));
+    }
+
+    private ErrorResponse error(String code, String message) {
+        return new ErrorResponse(code, message, clock);
+    }
+
+    /**
+     * Spring reports a zero-length body as a missing body, while a whitespace-only
+     * body reaches Jackson and fails with "No content to map".
+     */
+    private static boolean isMissingBody(HttpMessageNotReadableException ex) {
+        if (ex.getCause() == null) {
+            return ex.getMessage() != null && ex.getMessage().startsWith("Required request body is missing");
+        }
+        return ex.getCause() instanceof MismatchedInputException cause
+            && cause.getOriginalMessage().startsWith("No content to map due to end-of-input");
+    }
+
+    /**
+     * Turn a Jackson failure into an actionable message: unknown fields,
+     * type mismatches (naming the offending field) and malformed JSON.
+     */
+    private static String describeBodyError(Throwable cause) {
+        if (cause instanceof UnrecognizedPropertyException ex) {
+            return String.format("Unknown field '%s'", fieldPath(ex));
+        }
+        if (cause instanceof MismatchedInputException ex) {
+            if (ex.getPath().isEmpty()) {
+                return "Request body must be " + describeType(ex.getTargetType());
+            }
+            return String.format("Field '%s' must be %s", fieldPath(ex), describeType(ex.getTargetType()));
+        }
+        if (cause instanceof JsonProcessingException ex) {
+            return "Malformed JSON: " + ex.getOriginalMessage();
+        }
+        return "Invalid request body";
+    }
+
+    private static String fieldPath(JsonMappingException ex) {
+        return ex.getPath().stream()
+            .map(ref -> ref.getFieldName() != null ? ref.getFieldName() : "[" + ref.getIndex() + "]")
+            .collect(Collectors.joining("."))
+            .replace(".[", "[");
+    }
+
+    private static String describeType(Class<?> type) {
+        if (type == null) {
+            return "a valid value";
+        }
+        if (Number.class.isAssignableFrom(type) || (type.isPrimitive() && type != boolean.class)) {
+            return "a number";
+        }
+        if (type == Boolean.class || type == boolean.class) {
+            return "a boolean";
+        }
+        if (type == String.class) {
+            return "a string";
+        }
+        if (type.isArray() || Collection.class.isAssignableFrom(type)) {
+            return "an array";
+        }
+        return "a valid " + type.getSimpleName();
+    }
+}
@@ -0,0 +1,17 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when a query parameter is well-typed but has an unsupported value.
+ */
+public class InvalidParameterException extends RuntimeException {
+    private final String parameter;
+
+    public InvalidParameterException(String parameter, String message) {
+        super(message);
+        this.parameter = parameter;
+    }
+
+    public String getParameter() {
+        return parameter;
+    }
+}
@@ -0,0 +1,11 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when a write endpoint has been called more often than its limit
+ * allows, e.g. too many deck clones in a minute.
+ */
+public class RateLimitedException extends RuntimeException {
+    public RateLimitedException(String message) {
+        super(message);
+    }
+}
@@ -0,0 +1,23 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when a requested resource (card, deck) does not exist.
+ */
+public class ResourceNotFoundException extends RuntimeException {
+    private final String resource;
+    private final Object id;
+
+    public ResourceNotFoundException(String resource, Object id) {
+        super(resource + " " + id + " not found");
+        this.resource = resource;
+        this.id = id;
+    }
+
+    public String getResource() {
+        return resource;
+    }
+
+    public Object getId() {
+        return id;
+    }
+}
@@ -0,0 +1,12 @@
+package com.yugioh.exception;
+
+/**
+ * Thrown when a well-formed request body fails business validation, e.g. a
+ * blank deck name or a max cost below the deck's total cost. Mapped to 422 so
+ * clients can tell it apart from a body that does not parse (400).
+ */
+public class ValidationException extends RuntimeException {
+    public ValidationException(String message) {
+        super(message);
+    }
+}
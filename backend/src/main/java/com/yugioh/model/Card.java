@@ -51,6 +51,29 @@ public class Card {
     @Column(name = "updated_at")
     private LocalDateTime updatedAt;
 
+    /**
+     * A copy with the same values that is not attached to any persistence
+     * context, so it can be adjusted for a response without being saved.
+     */
+    public Card copy() {
+        Card copy = new Card();
+        copy.id = id;
+        copy.name = name;
+        copy.description = description;
+        copy.image = image;
+        copy.type = type;
+        copy.attribute = attribute;
+        copy.race = race;
+        copy.level = level;
+        copy.attackPoints = attackPoints;
+        copy.defensePoints = defensePoints;
+        copy.cost = cost;
+        copy.rarity = rarity;
+        copy.createdAt = createdAt;
+        copy.updatedAt = updatedAt;
+        return copy;
+    }
+
     // Getters and Setters
     public Integer getId() {
         return id;
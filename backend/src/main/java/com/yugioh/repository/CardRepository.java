@@ -1,16 +1,87 @@
 package com.yugioh.repository;
 
 import com.yugioh.model.Card;
+import org.springframework.data.domain.Page;
+import org.springframework.data.domain.Pageable;
 import org.springframework.data.jpa.repository.JpaRepository;
 import org.springframework.data.jpa.repository.JpaSpecificationExecutor;
 import org.springframework.data.jpa.repository.Query;
+import org.springframework.data.jpa.repository.QueryHints;
 import org.springframework.data.repository.query.Param;
 import org.springframework.stereotype.Repository;
 
+import jakarta.persistence.QueryHint;
 import java.util.List;
+import java.util.stream.Stream;
+
+import static org.hibernate.jpa.HibernateHints.HINT_FETCH_SIZE;
 
 @Repository
 public interface CardRepository extends JpaRepository<Card, Integer>, JpaSpecificationExecutor<Card> {
+    /** The card listing's filters, shared by the facet count queries. */
+    String LISTING_FILTERS = "(:startId IS NULL OR c.id >= :startId) AND " +
+        "(:excludeDeckId IS NULL OR c.id NOT IN " +
+        "(SELECT dc.cardId FROM DeckCard dc WHERE dc.deckId = :excludeDeckId))";
+
     @Query("SELECT c FROM Card c WHERE c.id IN :ids ORDER BY c.id")
     List<Card> findByIds(@Param("ids") List<Integer> ids);
+
+    /**
+     * Cards of the same type (and attribute, when given) ranked by closeness,
+     * where one point of cost weighs as much as 500 ATK/DEF. Spells and traps
+     * have no attribute and 0 ATK/DEF, so they rank by cost alone.
+     */
+    @Query("SELECT c FROM Card c WHERE c.id <> :id AND c.type = :type AND " +
+        "(:attribute IS NULL OR c.attribute = :attribute) " +
+        "ORDER BY ABS(c.cost - :cost) * 500 + ABS(c.attackPoints - :attack) + ABS(c.defensePoints - :defense), c.id")
+    List<Card> findSimilar(
+        @Param("id") Integer id,
+        @Param("type") String type,
+        @Param("attribute") String attribute,
+        @Param("cost") int cost,
+        @Param("attack") int attack,
+        @Param("defense") int defense,
+        Pageable pageable
+    );
+
+    /** Case-insensitive substring match on the name and/or description, as selected by the flags. */
+    String TEXT_SEARCH = "(:byName = true AND LOWER(c.name) LIKE LOWER(CONCAT('%', :query, '%'))) OR " +
+        "(:byDescription = true AND LOWER(c.description) LIKE LOWER(CONCAT('%', :query, '%')))";
+
+    @Query(value = "SELECT c FROM Card c WHERE " + TEXT_SEARCH + " ORDER BY c.id",
+        countQuery = "SELECT COUNT(c) FROM Card c WHERE " + TEXT_SEARCH)
+    Page<Card> search(
+        @Param("query") String query,
+        @Param("byName") boolean byName,
+        @Param("byDescription") boolean byDescription,
+        Pageable pageable
+    );
+
+    /** Cards in at least one deck, most decks first; each row is {Card, distinct deck count}. */
+    @Query(value = "SELECT c, COUNT(DISTINCT dc.deckId) FROM Card c " +
+        "JOIN DeckCard dc ON dc.cardId = c.id JOIN Deck d ON d.id = dc.deckId " +
+        "WHERE (:preset IS NULL OR d.isPreset = :preset) " +
+        "GROUP BY c ORDER BY COUNT(DISTINCT dc.deckId) DESC, c.id",
+        countQuery = "SELECT COUNT(DISTINCT dc.cardId) FROM DeckCard dc JOIN Deck d ON d.id = dc.deckId " +
+            "WHERE (:preset IS NULL OR d.isPreset = :preset)")
+    Page<Object[]> findPopular(@Param("preset") Boolean preset, Pageable pageable);
+
+    @QueryHints(@QueryHint(name = HINT_FETCH_SIZE, value = "50"))
+    @Query("SELECT c FROM Card c ORDER BY c.id")
+    Stream<Card> streamAll();
+
+    @Query("SELECT COUNT(c) FROM Card c WHERE " + LISTING_FILTERS)
+    long countWithFilters(@Param("startId") Integer startId, @Param("excludeDeckId") Integer excludeDeckId);
+
+    @Query("SELECT c.type, COUNT(c) FROM Card c WHERE c.type IS NOT NULL AND " + LISTING_FILTERS +
+        " GROUP BY c.type ORDER BY c.type")
+    List<Object[]> countByType(@Param("startId") Integer startId, @Param("excludeDeckId") Integer excludeDeckId);
+
+    @Query("SELECT c.attribute, COUNT(c) FROM Card c WHERE c.attribute IS NOT NULL AND " + LISTING_FILTERS +
+        " GROUP BY c.attribute ORDER BY c.attribute")
+    List<Object[]> countByAttribute(@Param("startId") Integer startId, @Param("excludeDeckId") Integer excludeDeckId);
+
+    @Query("SELECT c.rarity, COUNT(c) FROM Card c WHERE c.rarity IS NOT NULL AND " + LISTING_FILTERS +
+        " GROUP BY c.rarity ORDER BY c.rarity")
+    List<Object[]> countByRarity(@Param("startId") Integer startId, @Param("excludeDeckId") Integer excludeDeckId);
 }
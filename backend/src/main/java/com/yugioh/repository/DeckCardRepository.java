@@ -14,6 +14,10 @@ public interface DeckCardRepository extends JpaRepository<DeckCard, DeckCardId>
     @Query("SELECT dc.cardId FROM DeckCard dc WHERE dc.deckId = :deckId ORDER BY dc.position")
     List<Integer> findCardIdsByDeckId(@Param("deckId") Integer deckId);
 
+    List<DeckCard> findByDeckIdOrderByPosition(Integer deckId);
+
+    List<DeckCard> findByDeckIdInOrderByDeckIdAscPositionAsc(List<Integer> deckIds);
+
     long countByDeckId(Integer deckId);
 
     long countByDeckIdAndCardId(Integer deckId, Integer cardId);
@@ -8,24 +8,113 @@ import org.springframework.data.jpa.repository.Query;
 import org.springframework.data.repository.query.Param;
 import org.springframework.stereotype.Repository;
 
+import java.util.List;
+
 @Repository
 public interface DeckRepository extends JpaRepository<Deck, Integer> {
-    @Query("SELECT d FROM Deck d WHERE " +
-        "(:archetype IS NULL OR d.archetype = :archetype) AND " +
-        "(:presetOnly IS NULL OR d.isPreset = :presetOnly)")
+    /**
+     * The deck listing's filters. Total cost is computed from the cards, not
+     * stored, so the cost range is a HAVING over the aggregate join; keeping
+     * it in the WHERE (via IN) makes counts and pagination match the rows.
+     * Decks without cards have a total cost of 0.
+     */
+    String LISTING_FILTERS = "(:archetype IS NULL OR d.archetype = :archetype) AND " +
+        "(:presetOnly IS NULL OR d.isPreset = :presetOnly) AND " +
+        "((:minCost IS NULL AND :maxCost IS NULL) OR d.id IN (" +
+        "SELECT cd.id FROM Deck cd LEFT JOIN DeckCard cdc ON cdc.deckId = cd.id " +
+        "LEFT JOIN Card cc ON cc.id = cdc.cardId GROUP BY cd.id HAVING " +
+        "(:minCost IS NULL OR COALESCE(SUM(cc.cost), 0) >= :minCost) AND " +
+        "(:maxCost IS NULL OR COALESCE(SUM(cc.cost), 0) <= :maxCost)))";
+
+    @Query("SELECT d FROM Deck d WHERE " + LISTING_FILTERS)
     Page<Deck> findAllWithFilters(
         @Param("archetype") String archetype,
         @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost,
+        Pageable pageable
+    );
+
+    @Query(value = "SELECT d FROM Deck d " +
+        "LEFT JOIN DeckCard dc ON dc.deckId = d.id LEFT JOIN Card c ON c.id = dc.cardId WHERE " +
+        LISTING_FILTERS + " GROUP BY d ORDER BY COALESCE(SUM(c.cost), 0) ASC, d.id",
+        countQuery = "SELECT COUNT(d) FROM Deck d WHERE " + LISTING_FILTERS)
+    Page<Deck> findAllWithFiltersOrderByTotalCostAsc(
+        @Param("archetype") String archetype,
+        @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost,
+        Pageable pageable
+    );
+
+    @Query(value = "SELECT d FROM Deck d " +
+        "LEFT JOIN DeckCard dc ON dc.deckId = d.id LEFT JOIN Card c ON c.id = dc.cardId WHERE " +
+        LISTING_FILTERS + " GROUP BY d ORDER BY COALESCE(SUM(c.cost), 0) DESC, d.id",
+        countQuery = "SELECT COUNT(d) FROM Deck d WHERE " + LISTING_FILTERS)
+    Page<Deck> findAllWithFiltersOrderByTotalCostDesc(
+        @Param("archetype") String archetype,
+        @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost,
+        Pageable pageable
+    );
+
+    @Query(value = "SELECT d FROM Deck d " +
+        "LEFT JOIN DeckCard dc ON dc.deckId = d.id LEFT JOIN Card c ON c.id = dc.cardId WHERE " +
+        LISTING_FILTERS + " GROUP BY d ORDER BY COALESCE(SUM(c.attackPoints + c.defensePoints), 0) ASC, d.id",
+        countQuery = "SELECT COUNT(d) FROM Deck d WHERE " + LISTING_FILTERS)
+    Page<Deck> findAllWithFiltersOrderByTotalPowerAsc(
+        @Param("archetype") String archetype,
+        @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost,
+        Pageable pageable
+    );
+
+    @Query(value = "SELECT d FROM Deck d " +
+        "LEFT JOIN DeckCard dc ON dc.deckId = d.id LEFT JOIN Card c ON c.id = dc.cardId WHERE " +
+        LISTING_FILTERS + " GROUP BY d ORDER BY COALESCE(SUM(c.attackPoints + c.defensePoints), 0) DESC, d.id",
+        countQuery = "SELECT COUNT(d) FROM Deck d WHERE " + LISTING_FILTERS)
+    Page<Deck> findAllWithFiltersOrderByTotalPowerDesc(
+        @Param("archetype") String archetype,
+        @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost,
         Pageable pageable
     );
 
-    @Query("SELECT COUNT(d) FROM Deck d WHERE " +
-        "d.id < :deckId AND " +
-        "(:archetype IS NULL OR d.archetype = :archetype) AND " +
-        "(:presetOnly IS NULL OR d.isPreset = :presetOnly)")
+    @Query("SELECT COUNT(d) FROM Deck d WHERE " + LISTING_FILTERS)
+    long countWithFilters(
+        @Param("archetype") String archetype,
+        @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost
+    );
+
+    @Query("SELECT COUNT(d) FROM Deck d WHERE d.id < :deckId AND " + LISTING_FILTERS)
     long countDecksBeforeId(
         @Param("deckId") Integer deckId,
         @Param("archetype") String archetype,
-        @Param("presetOnly") Boolean presetOnly
+        @Param("presetOnly") Boolean presetOnly,
+        @Param("minCost") Integer minCost,
+        @Param("maxCost") Integer maxCost
     );
+
+    boolean existsByName(String name);
+
+    List<Deck> findByNameIgnoreCaseOrderById(String name);
+
+    @Query(value = "SELECT d FROM Deck d WHERE LOWER(d.name) LIKE LOWER(CONCAT('%', :query, '%')) ORDER BY d.name",
+        countQuery = "SELECT COUNT(d) FROM Deck d WHERE LOWER(d.name) LIKE LOWER(CONCAT('%', :query, '%'))")
+    Page<Deck> searchByName(@Param("query") String query, Pageable pageable);
+
+    @Query("SELECT d FROM Deck d WHERE d.id IN " +
+        "(SELECT dc.deckId FROM DeckCard dc WHERE dc.cardId = :cardId)")
+    Page<Deck> findDecksContainingCard(@Param("cardId") Integer cardId, Pageable pageable);
+
+    @Query("SELECT DISTINCT d.characterName FROM Deck d WHERE " +
+        "d.characterName IS NOT NULL AND d.id IN " +
+        "(SELECT dc.deckId FROM DeckCard dc WHERE dc.cardId = :cardId) " +
+        "ORDER BY d.characterName")
+    List<String> findCharacterNamesUsingCard(@Param("cardId") Integer cardId);
 }
@@ -0,0 +1,74 @@
+package com.yugioh.service;
+
+import com.yugioh.model.Card;
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.stereotype.Component;
+
+import java.net.URI;
+import java.net.URISyntaxException;
+
+/**
+ * Normalizes card image references before they are served.
+ * Absolute http(s) URLs pass through, relative paths are resolved against
+ * the configured image base, and anything empty or malformed falls back
+ * to the placeholder image.
+ */
+@Component
+public class CardImageResolver {
+    private final String baseUrl;
+    private final String placeholderUrl;
+
+    public CardImageResolver(
+            @Value("${card.image.base-url}") String baseUrl,
+            @Value("${card.image.placeholder-url}") String placeholderUrl) {
+        this.baseUrl = baseUrl == null ? "" : baseUrl.trim();
+        this.placeholderUrl = placeholderUrl;
+    }
+
+    public String resolve(String image) {
+        if (image == null || image.isBlank()) {
+            return placeholderUrl;
+        }
+
+        URI uri = parse(image.trim());
+        if (uri != null && !uri.isAbsolute() && !baseUrl.isEmpty()) {
+            uri = parse(stripTrailingSlash(baseUrl) + "/" + stripLeadingSlash(image.trim()));
+        }
+
+        return isHttpUrl(uri) ? uri.toString() : placeholderUrl;
+    }
+
+    /**
+     * Return a copy of the card with its image resolved. The card itself is
+     * left alone: it is usually a managed entity, and changing it inside a
+     * transaction would write the resolved URL back to the database.
+     */
+    public Card apply(Card card) {
+        Card resolved = card.copy();
+        resolved.setImage(resolve(card.getImage()));
+        return resolved;
+    }
+
+    private static URI parse(String value) {
+        try {
+            return new URI(value);
+        } catch (URISyntaxException e) {
+            return null;
+        }
+    }
+
+    private static boolean isHttpUrl(URI uri) {
+        if (uri == null || !uri.isAbsolute() || uri.getHost() == null) {
+            return false;
+        }
+        return "http".equalsIgnoreCase(uri.getScheme()) || "https".equalsIgnoreCase(uri.getScheme());
+    }
+
+    private static String stripTrailingSlash(String value) {
+        return value.endsWith("/") ? value.substring(0, value.length() - 1) : value;
+    }
+
+    private static String stripLeadingSlash(String value) {
+        return value.startsWith("/") ? value.substring(1) : value;
+    }
+}
@@ -0,0 +1,59 @@
+package com.yugioh.service;
+
+import org.springframework.beans.factory.annotation.Value;
+import org.springframework.stereotype.Component;
+
+import java.net.URI;
+import java.net.URISyntaxException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.Paths;
+import java.util.Optional;
+
+/**
+ * Looks up card image files in a local directory so the API can serve
+ * images itself when no CDN is available. Only the file name of the card's
+ * image reference is used, so lookups never leave the configured directory.
+ */
+@Component
+public class CardImageStore {
+    private final Path directory;
+
+    public CardImageStore(@Value("${card.image.dir}") String directory) {
+        this.directory = directory == null || directory.isBlank()
+            ? null
+            : Paths.get(directory.trim()).toAbsolutePath().normalize();
+    }
+
+    public Optional<Path> find(String image) {
+        String fileName = fileName(image);
+        if (directory == null || fileName == null) {
+            return Optional.empty();
+        }
+
+        Path file = directory.resolve(fileName).normalize();
+        if (!directory.equals(file.getParent()) || !Files.isRegularFile(file)) {
+            return Optional.empty();
+        }
+        return Optional.of(file);
+    }
+
+    private static String fileName(String image) {
+        if (image == null || image.isBlank()) {
+            return null;
+        }
+
+        String path;
+        try {
+            path = new URI(image.trim()).getPath();
+        } catch (URISyntaxException e) {
+            return null;
+        }
+        if (path == null) {
+            return null;
+        }
+
+        String name = path.substring(path.lastIndexOf('/') + 1);
+        return name.isEmpty() || name.equals(".") || name.equals("..") ? null : name;
+    }
+}
@@ -1,46 +1,256 @@
 package com.yugioh.service;
 
+import com.yugioh.config.DatabaseReadRetry;
+import com.yugioh.dto.PopularCard;
+import com.yugioh.exception.InvalidParameterException;
 import com.yugioh.model.Card;
+import com.yugioh.model.DeckCard;
 import com.yugioh.repository.CardRepository;
 import org.springframework.beans.factory.annotation.Autowired;
 import org.springframework.data.domain.Page;
+import org.springframework.data.domain.PageImpl;
 import org.springframework.data.domain.PageRequest;
 import org.springframework.data.domain.Pageable;
 import org.springframework.data.domain.Sort;
 import org.springframework.data.jpa.domain.Specification;
 import org.springframework.stereotype.Service;
+import org.springframework.transaction.annotation.Transactional;
 
+import jakarta.persistence.EntityManager;
+import jakarta.persistence.PersistenceContext;
+import jakarta.persistence.Tuple;
+import jakarta.persistence.criteria.CriteriaBuilder;
+import jakarta.persistence.criteria.CriteriaQuery;
 import jakarta.persistence.criteria.Predicate;
+import jakarta.persistence.criteria.Root;
+import jakarta.persistence.criteria.Selection;
+import jakarta.persistence.criteria.Subquery;
 import java.util.ArrayList;
+import java.util.LinkedHashMap;
 import java.util.List;
+import java.util.Map;
 import java.util.Optional;
+import java.util.function.Consumer;
+import java.util.stream.Stream;
 
 @Service
 public class CardService {
+    /** Maximum number of recommendations returned by {@link #getSimilarCards}. */
+    public static final int MAX_SIMILAR_CARDS = 50;
+
+    /** Shortest search text accepted, so a one-letter query cannot match nearly every card. */
+    public static final int MIN_SEARCH_LENGTH = 2;
+
+    /** Card fields the text search can match against. */
+    public static final List<String> SEARCH_FIELDS = List.of("name", "description");
+
     @Autowired
     private CardRepository cardRepository;
 
-    public Page<Card> getAllCards(int page, int limit, Integer startId) {
+    @Autowired
+    private CardImageResolver cardImageResolver;
+
+    @Autowired
+    private DatabaseReadRetry databaseReadRetry;
+
+    @PersistenceContext
+    private EntityManager entityManager;
+
+    public Page<Card> getAllCards(int page, int limit, Integer startId, Integer excludeDeckId) {
         Pageable pageable = PageRequest.of(page - 1, limit, Sort.by("id").ascending());
+        Specification<Card> spec = listFilter(startId, excludeDeckId);
+
+        if (spec != null) {
+            return databaseReadRetry.withRetry(() -> cardRepository.findAll(spec, pageable))
+                .map(cardImageResolver::apply);
+        }
+
+        return databaseReadRetry.withRetry(() -> cardRepository.findAll(pageable)).map(cardImageResolver::apply);
+    }
+
+    /**
+     * The same page as {@link #getAllCards}, but selecting only the given
+     * card fields in SQL. Each row maps field name to value in the order
+     * requested; an image field is resolved as it is for full cards.
+     */
+    public Page<Map<String, Object>> getAllCardFields(int page, int limit, Integer startId, Integer excludeDeckId,
+            List<String> fields) {
+        Pageable pageable = PageRequest.of(page - 1, limit);
+        Specification<Card> spec = listFilter(startId, excludeDeckId);
+        Integer fromId = startId != null && startId > 0 ? startId : null;
+
+        return databaseReadRetry.withRetry(() -> {
+            CriteriaBuilder cb = entityManager.getCriteriaBuilder();
+            CriteriaQuery<Tuple> query = cb.createTupleQuery();
+            Root<Card> root = query.from(Card.class);
+            query.multiselect(fields.stream().<Selection<?>>map(field -> root.get(field).alias(field)).toList());
+            if (spec != null) {
+                query.where(spec.toPredicate(root, query, cb));
+            }
+            query.orderBy(cb.asc(root.get("id")));
+
+            List<Map<String, Object>> rows = entityManager.createQuery(query)
+                .setFirstResult((int) pageable.getOffset())
+                .setMaxResults(limit)
+                .getResultList()
+                .stream()
+                .map(row -> toFieldMap(row, fields))
+                .toList();
+            return new PageImpl<>(rows, pageable, cardRepository.countWithFilters(fromId, excludeDeckId));
+        });
+    }
+
+    private Map<String, Object> toFieldMap(Tuple row, List<String> fields) {
+        Map<String, Object> values = new LinkedHashMap<>();
+        for (String field : fields) {
+            Object value = row.get(field);
+            values.put(field, "image".equals(field) ? cardImageResolver.resolve((String) value) : value);
+        }
+        return values;
+    }
+
+    /**
+     * The listing's filters, or null when there are none.
+     */
+    private static Specification<Card> listFilter(Integer startId, Integer excludeDeckId) {
+        boolean filterByStartId = startId != null && startId > 0;
+        if (!filterByStartId && excludeDeckId == null) {
+            return null;
+        }
 
-        if (startId != null && startId > 0) {
-            // Filter cards starting from startId
-            Specification<Card> spec = (root, query, cb) -> {
-                List<Predicate> predicates = new ArrayList<>();
+        return (root, query, cb) -> {
+            List<Predicate> predicates = new ArrayList<>();
+            if (filterByStartId) {
+                // Filter cards starting from startId
                 predicates.add(cb.greaterThanOrEqualTo(root.get("id"), startId));
-                return cb.and(predicates.toArray(new Predicate[0]));
-            };
-            return cardRepository.findAll(spec, pageable);
+            }
+            if (excludeDeckId != null) {
+                // Hide cards already in the deck; an unknown deck has no rows, so nothing is excluded
+                Subquery<Integer> deckCardIds = query.subquery(Integer.class);
+                Root<DeckCard> deckCard = deckCardIds.from(DeckCard.class);
+                deckCardIds.select(deckCard.get("cardId"))
+                    .where(cb.equal(deckCard.get("deckId"), excludeDeckId));
+                predicates.add(cb.not(root.get("id").in(deckCardIds)));
+            }
+            return cb.and(predicates.toArray(new Predicate[0]));
+        };
+    }
+
+    /**
+     * Count the cards the listing would return for these filters, without
+     * fetching any rows.
+     */
+    public long countCards(Integer startId, Integer excludeDeckId) {
+        Integer fromId = startId != null && startId > 0 ? startId : null;
+        return databaseReadRetry.withRetry(() -> cardRepository.countWithFilters(fromId, excludeDeckId));
+    }
+
+    /**
+     * Count cards per type, attribute and rarity under the same filters as
+     * the listing, plus the unfiltered grand total, for faceted browsing.
+     */
+    public Map<String, Object> getCardFacets(Integer startId, Integer excludeDeckId) {
+        Integer fromId = startId != null && startId > 0 ? startId : null;
+
+        return databaseReadRetry.withRetry(() -> {
+            Map<String, Object> facets = new LinkedHashMap<>();
+            facets.put("total", cardRepository.count());
+            facets.put("type", toCounts(cardRepository.countByType(fromId, excludeDeckId)));
+            facets.put("attribute", toCounts(cardRepository.countByAttribute(fromId, excludeDeckId)));
+            facets.put("rarity", toCounts(cardRepository.countByRarity(fromId, excludeDeckId)));
+            return facets;
+        });
+    }
+
+    private Map<String, Long> toCounts(List<Object[]> rows) {
+        Map<String, Long> counts = new LinkedHashMap<>();
+        rows.forEach(row -> counts.put((String) row[0], ((Number) row[1]).longValue()));
+        return counts;
+    }
+
+    /**
+     * Paginated, case-insensitive substring search over the given fields
+     * (name and/or description), in ID order.
+     */
+    public Page<Card> searchCards(String query, List<String> fields, int page, int limit) {
+        String text = query == null ? "" : query.trim();
+        if (text.length() < MIN_SEARCH_LENGTH) {
+            throw new InvalidParameterException("q",
+                String.format("Parameter 'q' must be at least %d characters", MIN_SEARCH_LENGTH));
+        }
+        for (String field : fields) {
+            if (!SEARCH_FIELDS.contains(field)) {
+                throw new InvalidParameterException("fields",
+                    String.format("Parameter 'fields' must only contain %s, got '%s'", SEARCH_FIELDS, field));
+            }
         }
 
-        return cardRepository.findAll(pageable);
+        Pageable pageable = PageRequest.of(page - 1, limit);
+        return databaseReadRetry.withRetry(() -> cardRepository.search(
+                text, fields.contains("name"), fields.contains("description"), pageable))
+            .map(cardImageResolver::apply);
+    }
+
+    /**
+     * Cards ranked by how many distinct decks include them (ties in ID
+     * order). preset narrows the decks counted to preset (true) or user
+     * (false) decks; null counts every deck.
+     */
+    public Page<PopularCard> getPopularCards(int page, int limit, Boolean preset) {
+        Pageable pageable = PageRequest.of(page - 1, limit);
+        return databaseReadRetry.withRetry(() -> cardRepository.findPopular(preset, pageable))
+            .map(row -> new PopularCard(cardImageResolver.apply((Card) row[0]), ((Number) row[1]).longValue()));
     }
 
     public Optional<Card> getCardById(Integer id) {
-        return cardRepository.findById(id);
+        return databaseReadRetry.withRetry(() -> cardRepository.findById(id)).map(cardImageResolver::apply);
+    }
+
+    /**
+     * The card's image reference as stored, before it is resolved to a URL.
+     * An empty value means the card does not exist; a card without an image
+     * gives an empty string.
+     */
+    public Optional<String> getStoredImage(Integer id) {
+        return databaseReadRetry.withRetry(() -> cardRepository.findById(id))
+            .map(card -> card.getImage() == null ? "" : card.getImage());
+    }
+
+    /**
+     * Recommend cards like the given one: same type and attribute, closest in
+     * cost, attack and defense first. The card itself is never included.
+     */
+    public List<Card> getSimilarCards(Card card, int limit) {
+        if (limit < 1 || limit > MAX_SIMILAR_CARDS) {
+            throw new InvalidParameterException("limit",
+                String.format("Parameter 'limit' must be between 1 and %d, got %d", MAX_SIMILAR_CARDS, limit));
+        }
+
+        List<Card> cards = databaseReadRetry.withRetry(() -> cardRepository.findSimilar(
+            card.getId(), card.getType(), card.getAttribute(),
+            card.getCost(), card.getAttackPoints(), card.getDefensePoints(),
+            PageRequest.of(0, limit)));
+        return cards.stream().map(cardImageResolver::apply).toList();
     }
 
     public List<Card> getCardsByIds(List<Integer> ids) {
-        return cardRepository.findByIds(ids);
+        List<Card> cards = databaseReadRetry.withRetry(() -> cardRepository.findByIds(ids));
+        return cards.stream().map(cardImageResolver::apply).toList();
+    }
+
+    /**
+     * Stream every card to the consumer in ID order from a single cursor-backed query.
+     * Closing the stream (including when the consumer throws on a client disconnect)
+     * releases the underlying result set. Each card is detached once written so the
+     * persistence context does not hold the whole table.
+     */
+    @Transactional(readOnly = true)
+    public void streamAllCards(Consumer<Card> consumer) {
+        try (Stream<Card> cards = cardRepository.streamAll()) {
+            cards.forEach(card -> {
+                consumer.accept(cardImageResolver.apply(card));
+                entityManager.detach(card);
+            });
+        }
     }
 }
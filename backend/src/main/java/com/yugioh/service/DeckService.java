@@ -1,9 +1,18 @@
 package com.yugioh.service;
 
+import com.yugioh.config.DatabaseReadRetry;
+import com.yugioh.config.DeckRules;
+import com.yugioh.dto.DeckComposition;
 import com.yugioh.dto.DeckSummary;
+import com.yugioh.dto.DeckUpdateRequest;
 import com.yugioh.dto.DeckWithCards;
+import com.yugioh.exception.ConflictException;
+import com.yugioh.exception.ForbiddenException;
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.exception.ValidationException;
 import com.yugioh.model.Card;
 import com.yugioh.model.Deck;
+import com.yugioh.model.DeckCard;
 import com.yugioh.repository.CardRepository;
 import com.yugioh.repository.DeckCardRepository;
 import com.yugioh.repository.DeckRepository;
@@ -11,16 +20,32 @@ import org.springframework.beans.factory.annotation.Autowired;
 import org.springframework.data.domain.Page;
 import org.springframework.data.domain.PageRequest;
 import org.springframework.data.domain.Pageable;
+import org.springframework.data.domain.Sort;
 import org.springframework.stereotype.Service;
+import org.springframework.transaction.annotation.Transactional;
 
+import java.time.Clock;
+import java.time.LocalDateTime;
+import java.util.Arrays;
 import java.util.List;
 import java.util.Map;
+import java.util.Objects;
 import java.util.Optional;
 import java.util.stream.Collectors;
 import java.util.function.Function;
+import java.util.function.Supplier;
 
 @Service
 public class DeckService {
+    /**
+     * Supported values for the deck listing's sort parameter; prefix with '-' for descending.
+     * "cost" is the total card cost and "power" the total attack plus defense, counting every copy.
+     */
+    public static final List<String> SORT_KEYS = List.of("cost", "-cost", "power", "-power");
+
+    /** Length of the decks.name column. */
+    static final int MAX_NAME_LENGTH = 255;
+
     @Autowired
     private DeckRepository deckRepository;
 
@@ -30,47 +55,143 @@ public class DeckService {
     @Autowired
     private CardRepository cardRepository;
 
-    public Page<DeckSummary> getAllDecks(int page, int limit, String archetype, Boolean presetOnly) {
+    @Autowired
+    private CardImageResolver cardImageResolver;
+
+    @Autowired
+    private DatabaseReadRetry databaseReadRetry;
+
+    @Autowired
+    private DeckRules deckRules;
+
+    @Autowired
+    private Clock clock;
+
+    /**
+     * List deck summaries matching the filters. minCost/maxCost bound the
+     * computed total cost (inclusive, either may be null).
+     */
+    public Page<DeckSummary> getAllDecks(int page, int limit, String archetype, Boolean presetOnly, String sort,
+                                         Integer minCost, Integer maxCost) {
+        checkCostRange(minCost, maxCost);
         Pageable pageable = PageRequest.of(page - 1, limit);
-        Page<Deck> decks = deckRepository.findAllWithFilters(archetype, presetOnly, pageable);
-
-        return decks.map(deck -> {
-            List<Integer> cardIds = deckCardRepository.findCardIdsByDeckId(deck.getId());
-            List<Card> cards = cardRepository.findByIds(cardIds);
-            int totalCost = cards.stream().mapToInt(Card::getCost).sum();
-            String mostCommonType = calculateMostCommonType(cards);
-
-            return new DeckSummary(
-                deck.getId(),
-                deck.getName(),
-                deck.getDescription(),
-                deck.getCharacterName(),
-                deck.getArchetype(),
-                mostCommonType,
-                deck.getMaxCost(),
-                totalCost,
-                cardIds.size(),
-                deck.getIsPreset()
-            );
-        });
+        Supplier<Page<Deck>> query = switch (sort == null ? "" : sort.trim()) {
+            case "" -> () -> deckRepository.findAllWithFilters(archetype, presetOnly, minCost, maxCost, pageable);
+            case "cost" -> () -> deckRepository.findAllWithFiltersOrderByTotalCostAsc(
+                archetype, presetOnly, minCost, maxCost, pageable);
+            case "-cost" -> () -> deckRepository.findAllWithFiltersOrderByTotalCostDesc(
+                archetype, presetOnly, minCost, maxCost, pageable);
+            case "power" -> () -> deckRepository.findAllWithFiltersOrderByTotalPowerAsc(
+                archetype, presetOnly, minCost, maxCost, pageable);
+            case "-power" -> () -> deckRepository.findAllWithFiltersOrderByTotalPowerDesc(
+                archetype, presetOnly, minCost, maxCost, pageable);
+            default -> throw new InvalidParameterException("sort",
+                String.format("Parameter 'sort' must be one of %s, got '%s'", SORT_KEYS, sort));
+        };
+
+        return databaseReadRetry.withRetry(() -> query.get().map(this::toSummary));
+    }
+
+    public long countDecks(String archetype, Boolean presetOnly, Integer minCost, Integer maxCost) {
+        checkCostRange(minCost, maxCost);
+        return databaseReadRetry.withRetry(() -> deckRepository.countWithFilters(archetype, presetOnly, minCost, maxCost));
+    }
+
+    private void checkCostRange(Integer minCost, Integer maxCost) {
+        if (minCost != null && maxCost != null && minCost > maxCost) {
+            throw new InvalidParameterException("min_cost",
+                String.format("Parameter 'min_cost' must not exceed 'max_cost', got %d > %d", minCost, maxCost));
+        }
+    }
+
+    public Page<DeckSummary> getDecksUsingCard(Integer cardId, int page, int limit) {
+        Pageable pageable = PageRequest.of(page - 1, limit, Sort.by("id").ascending());
+        return databaseReadRetry.withRetry(() ->
+            deckRepository.findDecksContainingCard(cardId, pageable).map(this::toSummary));
+    }
+
+    public List<String> getCharactersUsingCard(Integer cardId) {
+        return databaseReadRetry.withRetry(() -> deckRepository.findCharacterNamesUsingCard(cardId));
+    }
+
+    public Page<DeckSummary> searchDecksByName(String query, int page, int limit) {
+        Pageable pageable = PageRequest.of(page - 1, limit);
+        if (query == null || query.isBlank()) {
+            return Page.empty(pageable);
+        }
+        return databaseReadRetry.withRetry(() ->
+            deckRepository.searchByName(query.trim(), pageable).map(this::toSummary));
     }
 
-    public int calculatePageFromDeckId(int deckId, int limit, String archetype, Boolean presetOnly) {
+    public int calculatePageFromDeckId(int deckId, int limit, String archetype, Boolean presetOnly,
+                                       Integer minCost, Integer maxCost) {
         // Count how many decks come before this deck ID with the same filters
-        long countBefore = deckRepository.countDecksBeforeId(deckId, archetype, presetOnly);
+        long countBefore = databaseReadRetry.withRetry(() ->
+            deckRepository.countDecksBeforeId(deckId, archetype, presetOnly, minCost, maxCost));
         // Calculate which page this deck would be on (1-based)
         return (int) ((countBefore / limit) + 1);
     }
 
     public Optional<DeckWithCards> getDeckById(Integer id) {
-        Optional<Deck> deckOpt = deckRepository.findById(id);
-        if (deckOpt.isEmpty()) {
-            return Optional.empty();
+        return databaseReadRetry.withRetry(() -> deckRepository.findById(id).map(this::toDeckWithCards));
+    }
+
+    /**
+     * Get a deck's card IDs in position order (one entry per copy), without
+     * loading the cards themselves. Empty when the deck does not exist.
+     */
+    public Optional<List<Integer>> getDeckCardIds(Integer id) {
+        return databaseReadRetry.withRetry(() -> deckRepository.existsById(id)
+            ? Optional.of(deckCardRepository.findCardIdsByDeckId(id))
+            : Optional.empty());
+    }
+
+    /**
+     * Look up a deck by case-insensitive exact name, for shareable links.
+     * Throws a conflict when the name matches more than one deck.
+     */
+    public Optional<DeckWithCards> getDeckByName(String name) {
+        List<Deck> matches = databaseReadRetry.withRetry(() -> deckRepository.findByNameIgnoreCaseOrderById(name.trim()));
+        if (matches.size() > 1) {
+            throw new ConflictException(String.format("Deck name '%s' matches %d decks", name.trim(), matches.size()));
         }
+        return databaseReadRetry.withRetry(() -> matches.stream().findFirst().map(this::toDeckWithCards));
+    }
 
-        Deck deck = deckOpt.get();
-        List<Integer> cardIds = deckCardRepository.findCardIdsByDeckId(id);
-        List<Card> cards = cardRepository.findByIds(cardIds);
+    /**
+     * Load several decks with their cards, in request order (duplicates
+     * dropped). Cards for all decks come from a single lookup. IDs with no
+     * deck are left out; callers compare against the request to report them.
+     */
+    public List<DeckWithCards> getDecksByIds(List<Integer> ids) {
+        List<Integer> deckIds = ids.stream().distinct().toList();
+        return databaseReadRetry.withRetry(() -> {
+            Map<Integer, Deck> decksById = deckRepository.findAllById(deckIds).stream()
+                .collect(Collectors.toMap(Deck::getId, Function.identity()));
+            Map<Integer, List<Integer>> cardIdsByDeck = deckCardRepository
+                .findByDeckIdInOrderByDeckIdAscPositionAsc(List.copyOf(decksById.keySet())).stream()
+                .collect(Collectors.groupingBy(DeckCard::getDeckId,
+                    Collectors.mapping(DeckCard::getCardId, Collectors.toList())));
+            Map<Integer, Card> cardsById = findCardsById(cardIdsByDeck.values().stream()
+                .flatMap(List::stream)
+                .toList());
+
+            return deckIds.stream()
+                .map(decksById::get)
+                .filter(Objects::nonNull)
+                .map(deck -> toDeckWithCards(deck,
+                    resolveCards(cardIdsByDeck.getOrDefault(deck.getId(), List.of()), cardsById)))
+                .toList();
+        });
+    }
+
+    private DeckWithCards toDeckWithCards(Deck deck) {
+        List<Integer> cardIds = deckCardRepository.findCardIdsByDeckId(deck.getId());
+        return toDeckWithCards(deck, loadCards(cardIds));
+    }
+
+    private DeckWithCards toDeckWithCards(Deck deck, List<Card> deckCards) {
+        List<Card> cards = deckCards.stream().map(cardImageResolver::apply).toList();
         int totalCost = cards.stream().mapToInt(Card::getCost).sum();
         String mostCommonType = calculateMostCommonType(cards);
 
@@ -85,8 +206,193 @@ public class DeckService {
         deckWithCards.setMaxCost(deck.getMaxCost());
         deckWithCards.setTotalCost(totalCost);
         deckWithCards.setIsPreset(deck.getIsPreset());
+        deckWithCards.setComposition(calculateComposition(cards));
+
+        return deckWithCards;
+    }
 
-        return Optional.of(deckWithCards);
+    /**
+     * Copy a deck and its cards into a new, editable (non-preset) deck.
+     * The copy is named after the source with a "(Copy)" suffix, numbered
+     * if that name is already taken, and keeps the source's character.
+     */
+    @Transactional
+    public Optional<DeckWithCards> cloneDeck(Integer sourceId) {
+        Optional<Deck> sourceOpt = deckRepository.findById(sourceId);
+        if (sourceOpt.isEmpty()) {
+            return Optional.empty();
+        }
+
+        Deck source = sourceOpt.get();
+        LocalDateTime now = LocalDateTime.now(clock);
+        Deck clone = new Deck();
+        clone.setName(cloneName(source.getName()));
+        clone.setDescription(source.getDescription());
+        clone.setCharacterName(source.getCharacterName());
+        clone.setArchetype(source.getArchetype());
+        clone.setMostCommonType(source.getMostCommonType());
+        clone.setMaxCost(source.getMaxCost());
+        clone.setIsPreset(false);
+        clone.setCreatedAt(now);
+        clone.setUpdatedAt(now);
+        Deck saved = deckRepository.save(clone);
+
+        List<DeckCard> copies = deckCardRepository.findByDeckIdOrderByPosition(sourceId).stream()
+            .map(deckCard -> {
+                DeckCard copy = new DeckCard();
+                copy.setDeckId(saved.getId());
+                copy.setCardId(deckCard.getCardId());
+                copy.setPosition(deckCard.getPosition());
+                return copy;
+            })
+            .toList();
+        deckCardRepository.saveAll(copies);
+
+        return getDeckById(saved.getId());
+    }
+
+    /**
+     * Update a deck's name, description, archetype and/or max cost, leaving
+     * fields that are not provided and the card list as they are. The cost
+     * budget is re-checked only when max cost changes, and a new name must
+     * not belong to another deck. Preset decks are read-only.
+     */
+    @Transactional
+    public Optional<DeckWithCards> updateDeck(Integer id, DeckUpdateRequest update) {
+        Optional<Deck> deckOpt = deckRepository.findById(id);
+        if (deckOpt.isEmpty()) {
+            return Optional.empty();
+        }
+
+        Deck deck = deckOpt.get();
+        if (Boolean.TRUE.equals(deck.getIsPreset())) {
+            throw new ForbiddenException(String.format("Deck %d is a preset deck and cannot be modified", id));
+        }
+        if (update.getName() != null) {
+            if (update.getName().isBlank()) {
+                throw new ValidationException("Field 'name' must not be blank");
+            }
+            String name = update.getName().trim();
+            if (!name.equals(deck.getName()) && deckRepository.existsByName(name)) {
+                throw new ConflictException(String.format("Deck name '%s' is already taken", name));
+            }
+            deck.setName(name);
+        }
+        if (update.getDescription() != null) {
+            deck.setDescription(update.getDescription());
+        }
+        if (update.getArchetype() != null) {
+            deck.setArchetype(update.getArchetype());
+        }
+        if (update.getMaxCost() != null && !update.getMaxCost().equals(deck.getMaxCost())) {
+            if (update.getMaxCost() < 0) {
+                throw new ValidationException("Field 'maxCost' must not be negative");
+            }
+            int totalCost = loadCards(deckCardRepository.findCardIdsByDeckId(id)).stream()
+                .mapToInt(Card::getCost)
+                .sum();
+            if (totalCost > update.getMaxCost()) {
+                throw new ValidationException(String.format(
+                    "Deck total cost %d exceeds new max cost %d", totalCost, update.getMaxCost()));
+            }
+            deck.setMaxCost(update.getMaxCost());
+        }
+        deck.setUpdatedAt(LocalDateTime.now());
+        deckRepository.save(deck);
+
+        return getDeckById(id);
+    }
+
+    /**
+     * A free name for a copy of the named deck. The base name is cut short
+     * when needed so the suffix still fits in the name column.
+     */
+    private String cloneName(String name) {
+        String candidate = withSuffix(name, " (Copy)");
+        int suffix = 2;
+        while (deckRepository.existsByName(candidate)) {
+            candidate = withSuffix(name, " (Copy " + suffix++ + ")");
+        }
+        return candidate;
+    }
+
+    private static String withSuffix(String name, String suffix) {
+        int maxBase = MAX_NAME_LENGTH - suffix.length();
+        return (name.length() > maxBase ? name.substring(0, maxBase) : name) + suffix;
+    }
+
+    /**
+     * Fetch the cards for a deck's card IDs, one entry per ID, in the given
+     * (position) order. The IN query returns each card once sorted by ID, so
+     * the result is rebuilt from a lookup to keep the saved deck order and
+     * every copy of a card.
+     */
+    private List<Card> loadCards(List<Integer> cardIds) {
+        return resolveCards(cardIds, findCardsById(cardIds));
+    }
+
+    private Map<Integer, Card> findCardsById(List<Integer> cardIds) {
+        return cardRepository.findByIds(cardIds.stream().distinct().toList()).stream()
+            .collect(Collectors.toMap(Card::getId, Function.identity()));
+    }
+
+    private List<Card> resolveCards(List<Integer> cardIds, Map<Integer, Card> cardsById) {
+        return cardIds.stream()
+            .map(cardsById::get)
+            .filter(Objects::nonNull)
+            .toList();
+    }
+
+    private DeckSummary toSummary(Deck deck) {
+        List<Integer> cardIds = deckCardRepository.findCardIdsByDeckId(deck.getId());
+        List<Card> cards = loadCards(cardIds);
+        int totalCost = cards.stream().mapToInt(Card::getCost).sum();
+        String mostCommonType = calculateMostCommonType(cards);
+
+        DeckSummary summary = new DeckSummary(
+            deck.getId(),
+            deck.getName(),
+            deck.getDescription(),
+            deck.getCharacterName(),
+            deck.getArchetype(),
+            mostCommonType,
+            deck.getMaxCost(),
+            totalCost,
+            cardIds.size(),
+            deck.getIsPreset()
+        );
+        String legalityReason = deckRules.validate(cardIds, totalCost, deck.getMaxCost(),
+            Boolean.TRUE.equals(deck.getIsPreset()));
+        summary.setIsLegal(legalityReason == null);
+        summary.setLegalityReason(legalityReason);
+        return summary;
+    }
+
+    /**
+     * Count the deck's monsters, spells and traps (every copy counts) and the
+     * share of monsters among all cards.
+     */
+    private DeckComposition calculateComposition(List<Card> cards) {
+        int monsters = countType(cards, "Monster");
+        int spells = countType(cards, "Spell");
+        int traps = countType(cards, "Trap");
+        double monsterRatio = cards.isEmpty() ? 0.0 : Math.round(monsters * 100.0 / cards.size()) / 100.0;
+        return new DeckComposition(monsters, spells, traps, monsterRatio);
+    }
+
+    private int countType(List<Card> cards, String kind) {
+        return (int) cards.stream().filter(card -> isKind(card.getType(), kind)).count();
+    }
+
+    /**
+     * Whether a card type such as "Effect Monster" or "Spell Card" is of the
+     * given kind (Monster, Spell or Trap).
+     */
+    private static boolean isKind(String type, String kind) {
+        if (type == null) {
+            return false;
+        }
+        return Arrays.stream(type.trim().split("\\s+")).anyMatch(kind::equalsIgnoreCase);
     }
 
     /**
@@ -0,0 +1,142 @@
+package com.yugioh.config;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import javax.sql.DataSource;
+import java.sql.Connection;
+import java.sql.SQLException;
+import java.util.ArrayList;
+import java.util.List;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+import static org.mockito.Mockito.*;
+
+@DisplayName("DatabaseConnectionRetry Tests")
+class DatabaseConnectionRetryTest {
+
+    private final List<Long> sleeps = new ArrayList<>();
+
+    @Test
+    @DisplayName("Should not retry when the database is available")
+    void postProcess_WhenDatabaseAvailable_ConnectsOnce() throws SQLException {
+        // Given
+        DataSource dataSource = mock(DataSource.class);
+        when(dataSource.getConnection()).thenReturn(mock(Connection.class));
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(3, 100, sleeps::add);
+
+        // When
+        Object result = retry.postProcessAfterInitialization(dataSource, "dataSource");
+
+        // Then
+        assertThat(result).isSameAs(dataSource);
+        assertThat(sleeps).isEmpty();
+        verify(dataSource, times(1)).getConnection();
+    }
+
+    @Test
+    @DisplayName("Should retry with backoff until the database becomes available")
+    void postProcess_WhenDatabaseRecovers_RetriesWithBackoff() throws SQLException {
+        // Given
+        DataSource dataSource = mock(DataSource.class);
+        when(dataSource.getConnection())
+            .thenThrow(new SQLException("Connection refused"))
+            .thenThrow(new SQLException("Connection refused"))
+            .thenReturn(mock(Connection.class));
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(5, 100, sleeps::add);
+
+        // When
+        retry.postProcessAfterInitialization(dataSource, "dataSource");
+
+        // Then
+        assertThat(sleeps).containsExactly(100L, 200L);
+        verify(dataSource, times(3)).getConnection();
+    }
+
+    @Test
+    @DisplayName("Should give up after the configured number of attempts")
+    void postProcess_WhenDatabaseStaysDown_Throws() throws SQLException {
+        // Given
+        DataSource dataSource = mock(DataSource.class);
+        when(dataSource.getConnection()).thenThrow(new SQLException("Connection refused"));
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(3, 100, sleeps::add);
+
+        // When / Then
+        assertThatThrownBy(() -> retry.postProcessAfterInitialization(dataSource, "dataSource"))
+            .isInstanceOf(IllegalStateException.class)
+            .hasMessage("Database unavailable after 3 attempts")
+            .hasCauseInstanceOf(SQLException.class);
+        assertThat(sleeps).containsExactly(100L, 200L);
+    }
+
+    @Test
+    @DisplayName("Should skip the check when max attempts is zero")
+    void postProcess_WhenDisabled_DoesNotConnect() throws SQLException {
+        // Given
+        DataSource dataSource = mock(DataSource.class);
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(0, 100);
+
+        // When
+        retry.postProcessAfterInitialization(dataSource, "dataSource");
+
+        // Then
+        verify(dataSource, never()).getConnection();
+    }
+
+    @Test
+    @DisplayName("Should leave beans other than data sources untouched")
+    void postProcess_WithOtherBean_ReturnsBean() {
+        // Given
+        Object bean = new Object();
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(3, 100, sleeps::add);
+
+        // When / Then
+        assertThat(retry.postProcessAfterInitialization(bean, "other")).isSameAs(bean);
+    }
+
+    @Test
+    @DisplayName("Should sleep between attempts with the default sleeper")
+    void waitForConnection_WithDefaultSleeper_Sleeps() throws SQLException {
+        // Given
+        DataSource dataSource = mock(DataSource.class);
+        when(dataSource.getConnection())
+            .thenThrow(new SQLException("Connection refused"))
+            .thenReturn(mock(Connection.class));
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(2, 1);
+
+        // When
+        retry.waitForConnection(dataSource);
+
+        // Then
+        verify(dataSource, times(2)).getConnection();
+    }
+
+    @Test
+    @DisplayName("Should stop waiting when interrupted")
+    void waitForConnection_WhenInterrupted_Throws() throws SQLException {
+        // Given
+        DataSource dataSource = mock(DataSource.class);
+        when(dataSource.getConnection()).thenThrow(new SQLException("Connection refused"));
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(3, 100, millis -> {
+            throw new InterruptedException();
+        });
+
+        // When / Then
+        assertThatThrownBy(() -> retry.waitForConnection(dataSource))
+            .isInstanceOf(IllegalStateException.class)
+            .hasMessage("Interrupted while waiting for the database");
+        assertThat(Thread.interrupted()).isTrue();
+    }
+
+    @Test
+    @DisplayName("Should cap the backoff interval")
+    void backoff_IsCapped() {
+        DatabaseConnectionRetry retry = new DatabaseConnectionRetry(10, 1000, sleeps::add);
+
+        assertThat(retry.backoff(1)).isEqualTo(1000L);
+        assertThat(retry.backoff(3)).isEqualTo(4000L);
+        assertThat(retry.backoff(10)).isEqualTo(DatabaseConnectionRetry.MAX_INTERVAL_MS);
+        assertThat(retry.backoff(64)).isEqualTo(DatabaseConnectionRetry.MAX_INTERVAL_MS);
+    }
+}
@@ -0,0 +1,91 @@
+package com.yugioh.config;
+
+import com.yugioh.exception.DatabaseUnavailableException;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.dao.DataAccessResourceFailureException;
+import org.springframework.dao.DataIntegrityViolationException;
+
+import java.sql.SQLException;
+import java.util.ArrayList;
+import java.util.List;
+import java.util.concurrent.atomic.AtomicInteger;
+import java.util.function.Supplier;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("DatabaseReadRetry Tests")
+class DatabaseReadRetryTest {
+
+    private final List<Long> sleeps = new ArrayList<>();
+    private final AtomicInteger calls = new AtomicInteger();
+    private final DatabaseReadRetry retry = new DatabaseReadRetry(200, sleeps::add);
+
+    private Supplier<String> failingTimes(int failures, RuntimeException error) {
+        return () -> {
+            if (calls.incrementAndGet() <= failures) {
+                throw error;
+            }
+            return "ok";
+        };
+    }
+
+    @Test
+    @DisplayName("Should run the query once when it succeeds")
+    void withRetry_WhenQuerySucceeds_RunsOnce() {
+        // When
+        String result = retry.withRetry(failingTimes(0, null));
+
+        // Then
+        assertThat(result).isEqualTo("ok");
+        assertThat(calls).hasValue(1);
+        assertThat(sleeps).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should retry once after a dropped connection")
+    void withRetry_WhenConnectionDropsOnce_RetriesAfterDelay() {
+        // When
+        String result = retry.withRetry(failingTimes(1, new DataAccessResourceFailureException("Connection reset")));
+
+        // Then
+        assertThat(result).isEqualTo("ok");
+        assertThat(calls).hasValue(2);
+        assertThat(sleeps).containsExactly(200L);
+    }
+
+    @Test
+    @DisplayName("Should report the database as unavailable when the retry also fails")
+    void withRetry_WhenConnectionStaysDown_ThrowsUnavailable() {
+        // When / Then
+        assertThatThrownBy(() -> retry.withRetry(failingTimes(2, new DataAccessResourceFailureException("Connection refused"))))
+            .isInstanceOf(DatabaseUnavailableException.class)
+            .hasCauseInstanceOf(DataAccessResourceFailureException.class);
+        assertThat(calls).hasValue(2);
+    }
+
+    @Test
+    @DisplayName("Should not retry errors unrelated to the connection")
+    void withRetry_WhenQueryFailsOtherwise_Rethrows() {
+        // Given
+        DataIntegrityViolationException error = new DataIntegrityViolationException("duplicate key");
+
+        // When / Then
+        assertThatThrownBy(() -> retry.withRetry(failingTimes(1, error))).isSameAs(error);
+        assertThat(calls).hasValue(1);
+        assertThat(sleeps).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should treat SQLState class 08 in the cause chain as a connection error")
+    void isConnectionError_WithConnectionSqlState_ReturnsTrue() {
+        // Given
+        RuntimeException wrapped = new RuntimeException(new SQLException("I/O error", "08006"));
+        RuntimeException other = new RuntimeException(new SQLException("syntax error", "42601"));
+
+        // Then
+        assertThat(DatabaseReadRetry.isConnectionError(wrapped)).isTrue();
+        assertThat(DatabaseReadRetry.isConnectionError(other)).isFalse();
+    }
+}
@@ -0,0 +1,75 @@
+package com.yugioh.config;
+
+import com.zaxxer.hikari.HikariDataSource;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import java.util.Properties;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("DatabaseSslCustomizer Tests")
+class DatabaseSslCustomizerTest {
+
+    @Test
+    @DisplayName("Should add SSL mode and certificate paths to the connection properties")
+    void postProcess_WithSslSettings_AddsDriverProperties() {
+        // Given
+        DatabaseSslCustomizer customizer = new DatabaseSslCustomizer(
+            "verify-full", "/certs/root.crt", "/certs/client.crt", " /certs/client.pk8 ");
+        HikariDataSource dataSource = new HikariDataSource();
+
+        // When
+        Object result = customizer.postProcessBeforeInitialization(dataSource, "dataSource");
+
+        // Then
+        assertThat(result).isSameAs(dataSource);
+        Properties properties = dataSource.getDataSourceProperties();
+        assertThat(properties)
+            .containsEntry("sslmode", "verify-full")
+            .containsEntry("sslrootcert", "/certs/root.crt")
+            .containsEntry("sslcert", "/certs/client.crt")
+            .containsEntry("sslkey", "/certs/client.pk8");
+    }
+
+    @Test
+    @DisplayName("Should leave out unset SSL settings")
+    void postProcess_WithModeOnly_OnlySetsMode() {
+        // Given
+        DatabaseSslCustomizer customizer = new DatabaseSslCustomizer("disable", "", null, "  ");
+        HikariDataSource dataSource = new HikariDataSource();
+
+        // When
+        customizer.postProcessBeforeInitialization(dataSource, "dataSource");
+
+        // Then
+        assertThat(customizer.getSslProperties()).containsOnlyKeys("sslmode");
+        assertThat(dataSource.getDataSourceProperties()).containsOnlyKeys("sslmode");
+    }
+
+    @Test
+    @DisplayName("Should set no SSL properties by default so the driver keeps sslmode=prefer")
+    void postProcess_WithDefaults_SetsNothing() {
+        // Given
+        DatabaseSslCustomizer customizer = new DatabaseSslCustomizer("", "", "", "");
+        HikariDataSource dataSource = new HikariDataSource();
+
+        // When
+        customizer.postProcessBeforeInitialization(dataSource, "dataSource");
+
+        // Then
+        assertThat(customizer.getSslProperties()).isEmpty();
+        assertThat(dataSource.getDataSourceProperties()).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should leave beans other than connection pools untouched")
+    void postProcess_WithOtherBean_ReturnsBean() {
+        // Given
+        DatabaseSslCustomizer customizer = new DatabaseSslCustomizer("require", "", "", "");
+        Object bean = new Object();
+
+        // When / Then
+        assertThat(customizer.postProcessBeforeInitialization(bean, "other")).isSameAs(bean);
+    }
+}
@@ -0,0 +1,85 @@
+package com.yugioh.config;
+
+import com.yugioh.exception.ForbiddenException;
+import com.yugioh.exception.RateLimitedException;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import java.time.Clock;
+import java.time.Duration;
+import java.time.Instant;
+import java.time.ZoneId;
+import java.time.ZoneOffset;
+
+import static org.assertj.core.api.Assertions.assertThatCode;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("DeckCloneLimit Tests")
+class DeckCloneLimitTest {
+
+    private final SteppingClock clock = new SteppingClock(Instant.parse("2026-03-01T12:00:00Z"));
+
+    @Test
+    @DisplayName("Should forbid cloning when it is disabled")
+    void acquire_WhenDisabled_ThrowsForbidden() {
+        DeckCloneLimit limit = new DeckCloneLimit(false, 10, clock);
+
+        assertThatThrownBy(limit::acquire)
+            .isInstanceOf(ForbiddenException.class)
+            .hasMessage("Deck cloning is disabled on this server");
+    }
+
+    @Test
+    @DisplayName("Should reject clones past the per-minute limit until the minute is over")
+    void acquire_PastLimit_ThrowsUntilNextMinute() {
+        // Given
+        DeckCloneLimit limit = new DeckCloneLimit(true, 2, clock);
+        limit.acquire();
+        limit.acquire();
+
+        // When / Then
+        assertThatThrownBy(limit::acquire)
+            .isInstanceOf(RateLimitedException.class)
+            .hasMessage("At most 2 decks can be cloned per minute, try again later");
+        clock.advance(Duration.ofSeconds(59));
+        assertThatThrownBy(limit::acquire).isInstanceOf(RateLimitedException.class);
+        clock.advance(Duration.ofSeconds(1));
+        assertThatCode(limit::acquire).doesNotThrowAnyException();
+    }
+
+    @Test
+    @DisplayName("Should reject a limit below one")
+    void constructor_WithZeroLimit_Throws() {
+        assertThatThrownBy(() -> new DeckCloneLimit(true, 0, clock))
+            .isInstanceOf(IllegalArgumentException.class)
+            .hasMessage("app.decks.clones-per-minute must be at least 1, got 0");
+    }
+
+    /** A UTC clock the test moves forward by hand. */
+    private static final class SteppingClock extends Clock {
+        private Instant now;
+
+        SteppingClock(Instant start) {
+            this.now = start;
+        }
+
+        void advance(Duration duration) {
+            now = now.plus(duration);
+        }
+
+        @Override
+        public ZoneId getZone() {
+            return ZoneOffset.UTC;
+        }
+
+        @Override
+        public Clock withZone(ZoneId zone) {
+            throw new UnsupportedOperationException();
+        }
+
+        @Override
+        public Instant instant() {
+            return now;
+        }
+    }
+}
@@ -0,0 +1,104 @@
+package com.yugioh.config;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import java.util.Collections;
+import java.util.List;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("DeckRules Tests")
+class DeckRulesTest {
+
+    private static final List<Integer> FOUR_COPIES = List.of(7, 7, 7, 7, 1);
+
+    /** Rules with the given copy limit and no minimum size, to test the other rules on small decks. */
+    private static DeckRules withCopyLimit(int maxCopies) {
+        return new DeckRules(maxCopies, 0, DeckRules.DEFAULT_MAX_DECK_SIZE);
+    }
+
+    @Test
+    @DisplayName("Should reject 4 copies of a card with a limit of 3")
+    void validate_FourCopiesWithLimitThree_IsRejected() {
+        DeckRules rules = withCopyLimit(3);
+
+        assertThat(rules.validate(FOUR_COPIES, 10, 100, false)).isEqualTo("Card 7 has 4 copies, max is 3");
+    }
+
+    @Test
+    @DisplayName("Should accept 4 copies of a card with a limit of 4")
+    void validate_FourCopiesWithLimitFour_IsAccepted() {
+        DeckRules rules = withCopyLimit(4);
+
+        assertThat(rules.validate(FOUR_COPIES, 10, 100, false)).isNull();
+    }
+
+    @Test
+    @DisplayName("Should reject any duplicate in a singleton format")
+    void validate_DuplicateWithLimitOne_IsRejected() {
+        DeckRules rules = withCopyLimit(1);
+
+        assertThat(rules.validate(List.of(1, 2, 2), 10, 100, false)).isEqualTo("Card 2 has 2 copies, max is 1");
+    }
+
+    @Test
+    @DisplayName("Should reject a deck over its cost budget")
+    void validate_OverBudget_IsRejected() {
+        DeckRules rules = withCopyLimit(3);
+
+        assertThat(rules.validate(List.of(1, 2), 101, 100, false)).isEqualTo("Total cost 101 exceeds budget of 100");
+    }
+
+    @Test
+    @DisplayName("Should reject a deck over the maximum size")
+    void validate_OverMaxSize_IsRejected() {
+        DeckRules rules = new DeckRules(61, 40, 60);
+
+        assertThat(rules.validate(Collections.nCopies(61, 1), 0, null, false))
+            .isEqualTo("Deck has 61 cards, max is 60");
+    }
+
+    @Test
+    @DisplayName("Should reject a deck under the minimum size")
+    void validate_UnderMinSize_IsRejected() {
+        DeckRules rules = new DeckRules(3, 40, 60);
+
+        assertThat(rules.validate(Collections.nCopies(39, 1), 0, null, false))
+            .isEqualTo("Deck has 39 cards, min is 40");
+    }
+
+    @Test
+    @DisplayName("Should accept decks at either end of the size range")
+    void validate_AtSizeBounds_IsAccepted() {
+        DeckRules rules = new DeckRules(60, 40, 60);
+
+        assertThat(rules.validate(Collections.nCopies(40, 1), 0, null, false)).isNull();
+        assertThat(rules.validate(Collections.nCopies(60, 1), 0, null, false)).isNull();
+    }
+
+    @Test
+    @DisplayName("Should exempt preset decks from the size range")
+    void validate_PresetOutsideSizeRange_IsAccepted() {
+        DeckRules rules = new DeckRules(3, 40, 60);
+
+        assertThat(rules.validate(List.of(1, 2, 3), 0, null, true)).isNull();
+    }
+
+    @Test
+    @DisplayName("Should refuse a maximum size below the minimum")
+    void constructor_WithMaxBelowMin_Throws() {
+        assertThatThrownBy(() -> new DeckRules(3, 40, 30))
+            .isInstanceOf(IllegalArgumentException.class)
+            .hasMessage("deck.max-size must be at least 1 and at least deck.min-size (40), got 30");
+    }
+
+    @Test
+    @DisplayName("Should refuse a copy limit below 1")
+    void constructor_WithZeroLimit_Throws() {
+        assertThatThrownBy(() -> new DeckRules(0, 40, 60))
+            .isInstanceOf(IllegalArgumentException.class)
+            .hasMessage("deck.max-card-copies must be at least 1, got 0");
+    }
+}
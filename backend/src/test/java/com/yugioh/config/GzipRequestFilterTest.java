@@ -0,0 +1,120 @@
+package com.yugioh.config;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import jakarta.servlet.http.HttpServletRequest;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.mock.web.MockFilterChain;
+import org.springframework.mock.web.MockHttpServletRequest;
+import org.springframework.mock.web.MockHttpServletResponse;
+
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.time.Clock;
+import java.time.Instant;
+import java.time.ZoneOffset;
+import java.util.zip.GZIPOutputStream;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("GzipRequestFilter Tests")
+class GzipRequestFilterTest {
+
+    private final GzipRequestFilter filter = new GzipRequestFilter(
+        64, Clock.fixed(Instant.parse("2026-03-01T12:00:00Z"), ZoneOffset.UTC), new ObjectMapper());
+
+    @Test
+    @DisplayName("Should pass a decompressed body downstream")
+    void doFilter_WithGzipBody_DecompressesIt() throws Exception {
+        // Given
+        MockHttpServletRequest request = gzipRequest(gzip("[1,2,3]"));
+        MockFilterChain chain = new MockFilterChain();
+
+        // When
+        filter.doFilter(request, new MockHttpServletResponse(), chain);
+
+        // Then
+        HttpServletRequest forwarded = (HttpServletRequest) chain.getRequest();
+        assertThat(new String(forwarded.getInputStream().readAllBytes(), StandardCharsets.UTF_8)).isEqualTo("[1,2,3]");
+        assertThat(forwarded.getHeader("Content-Encoding")).isNull();
+        assertThat(forwarded.getContentLength()).isEqualTo(7);
+    }
+
+    @Test
+    @DisplayName("Should leave requests without gzip encoding untouched")
+    void doFilter_WithoutGzip_PassesRequestThrough() throws Exception {
+        // Given
+        MockHttpServletRequest request = new MockHttpServletRequest("POST", "/cards/batch");
+        request.setContent("[1]".getBytes(StandardCharsets.UTF_8));
+        MockFilterChain chain = new MockFilterChain();
+
+        // When
+        filter.doFilter(request, new MockHttpServletResponse(), chain);
+
+        // Then
+        assertThat(chain.getRequest()).isSameAs(request);
+    }
+
+    @Test
+    @DisplayName("Should reject malformed gzip with 400")
+    void doFilter_WithMalformedGzip_ReturnsBadRequest() throws Exception {
+        // Given
+        MockHttpServletRequest request = gzipRequest("not gzip".getBytes(StandardCharsets.UTF_8));
+        MockHttpServletResponse response = new MockHttpServletResponse();
+        MockFilterChain chain = new MockFilterChain();
+
+        // When
+        filter.doFilter(request, response, chain);
+
+        // Then
+        assertThat(response.getStatus()).isEqualTo(400);
+        assertThat(response.getContentAsString()).contains("\"code\":\"invalid_body\"")
+            .contains("Request body is not valid gzip")
+            .contains("\"timestamp\":\"2026-03-01T12:00:00Z\"");
+        assertThat(chain.getRequest()).isNull();
+    }
+
+    @Test
+    @DisplayName("Should stop inflating and return 413 past the size limit")
+    void doFilter_WithOversizedBody_ReturnsPayloadTooLarge() throws Exception {
+        // Given: 65 bytes inflated against a 64-byte limit
+        MockHttpServletRequest request = gzipRequest(gzip("x".repeat(65)));
+        MockHttpServletResponse response = new MockHttpServletResponse();
+        MockFilterChain chain = new MockFilterChain();
+
+        // When
+        filter.doFilter(request, response, chain);
+
+        // Then
+        assertThat(response.getStatus()).isEqualTo(413);
+        assertThat(response.getContentAsString()).contains("\"code\":\"payload_too_large\"")
+            .contains("Decompressed request body exceeds 64 bytes");
+        assertThat(chain.getRequest()).isNull();
+    }
+
+    @Test
+    @DisplayName("Should reject a non-positive size limit")
+    void constructor_WithNonPositiveLimit_Throws() {
+        assertThatThrownBy(() -> new GzipRequestFilter(0, Clock.systemUTC(), new ObjectMapper()))
+            .isInstanceOf(IllegalArgumentException.class)
+            .hasMessage("app.request.max-decompressed-bytes must be at least 1, got 0");
+    }
+
+    private static MockHttpServletRequest gzipRequest(byte[] body) {
+        MockHttpServletRequest request = new MockHttpServletRequest("POST", "/cards/batch");
+        request.addHeader("Content-Encoding", "gzip");
+        request.setContentType("application/json");
+        request.setContent(body);
+        return request;
+    }
+
+    private static byte[] gzip(String text) throws IOException {
+        ByteArrayOutputStream out = new ByteArrayOutputStream();
+        try (GZIPOutputStream gzip = new GZIPOutputStream(out)) {
+            gzip.write(text.getBytes(StandardCharsets.UTF_8));
+        }
+        return out.toByteArray();
+    }
+}
@@ -38,8 +38,19 @@ class OpenApiConfigTest {
         assertThat(servers).isNotNull();
         assertThat(servers).hasSize(2);
         assertThat(servers.get(0).getUrl()).isEqualTo("http://localhost:8080");
-        assertThat(servers.get(0).getDescription()).isEqualTo("Local development server");
+        assertThat(servers.get(0).getDescription()).isEqualTo("API server");
         assertThat(servers.get(1).getUrl()).isEqualTo("http://backend:8080");
         assertThat(servers.get(1).getDescription()).isEqualTo("Docker container server");
     }
+
+    @Test
+    @DisplayName("Should list the configured server URL first")
+    void customOpenAPI_WithServerUrl_ListsItFirst() {
+        // When
+        OpenAPI api = new OpenApiConfig().customOpenAPI("https://api.example.com");
+
+        // Then
+        assertThat(api.getServers()).extracting(Server::getUrl)
+            .containsExactly("https://api.example.com", "http://backend:8080");
+    }
 }
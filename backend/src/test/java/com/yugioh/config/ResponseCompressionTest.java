@@ -0,0 +1,215 @@
+package com.yugioh.config;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.yugioh.controller.CardController;
+import com.yugioh.model.Card;
+import com.yugioh.service.CardImageStore;
+import com.yugioh.service.CardService;
+import com.yugioh.service.DeckService;
+import jakarta.servlet.http.HttpServlet;
+import jakarta.servlet.http.HttpServletRequest;
+import jakarta.servlet.http.HttpServletResponse;
+import org.junit.jupiter.api.AfterAll;
+import org.junit.jupiter.api.BeforeAll;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.boot.context.properties.bind.Binder;
+import org.springframework.boot.web.embedded.tomcat.TomcatServletWebServerFactory;
+import org.springframework.boot.web.server.Compression;
+import org.springframework.boot.web.server.WebServer;
+import org.springframework.beans.factory.config.BeanFactoryPostProcessor;
+import org.springframework.context.annotation.Bean;
+import org.springframework.context.annotation.Configuration;
+import org.springframework.core.env.StandardEnvironment;
+import org.springframework.core.io.ClassPathResource;
+import org.springframework.core.io.support.ResourcePropertySource;
+import org.springframework.web.context.support.AnnotationConfigWebApplicationContext;
+import org.springframework.web.servlet.DispatcherServlet;
+import org.springframework.web.servlet.config.annotation.EnableWebMvc;
+
+import java.io.ByteArrayInputStream;
+import java.io.IOException;
+import java.net.URI;
+import java.net.http.HttpClient;
+import java.net.http.HttpRequest;
+import java.net.http.HttpResponse;
+import java.nio.charset.StandardCharsets;
+import java.time.LocalDateTime;
+import java.util.Optional;
+import java.util.zip.GZIPInputStream;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.mockito.Mockito.mock;
+import static org.mockito.Mockito.when;
+
+/**
+ * Runs an embedded Tomcat with the server.compression settings from
+ * application.properties, so the Vary and Content-Encoding headers checked
+ * here are the ones the API actually sends. GET /cards/1 goes through the
+ * real CardController, to check its conditional responses under compression.
+ */
+@DisplayName("Response Compression Tests")
+class ResponseCompressionTest {
+
+    private static final String BODY = "{\"cards\":[" + "{\"name\":\"Blue-Eyes White Dragon\"},".repeat(60) + "{}]}";
+
+    /** The card's updated_at as an HTTP date; sessions run in UTC. */
+    private static final String CARD_LAST_MODIFIED = "Sun, 01 Mar 2026 12:00:00 GMT";
+
+    private static final HttpClient client = HttpClient.newHttpClient();
+
+    private static WebServer server;
+
+    @BeforeAll
+    static void startServer() throws IOException {
+        TomcatServletWebServerFactory factory = new TomcatServletWebServerFactory(0);
+        factory.setCompression(configuredCompression());
+        server = factory.getWebServer(context -> {
+            context.addServlet("body", new HttpServlet() {
+                @Override
+                protected void service(HttpServletRequest request, HttpServletResponse response) throws IOException {
+                    String uri = request.getRequestURI();
+                    response.setContentType(uri.endsWith(".png") ? "image/png"
+                        : uri.endsWith("/stream") ? "application/x-ndjson" : "application/json");
+                    response.getOutputStream().write(BODY.getBytes(StandardCharsets.UTF_8));
+                }
+            }).addMapping("/*");
+
+            // An exact mapping takes precedence over /*
+            AnnotationConfigWebApplicationContext mvc = new AnnotationConfigWebApplicationContext();
+            mvc.register(CardEndpoint.class);
+            mvc.addBeanFactoryPostProcessor(cardServices());
+            context.addServlet("dispatcher", new DispatcherServlet(mvc)).addMapping("/cards/1");
+        });
+        server.start();
+    }
+
+    @AfterAll
+    static void stopServer() {
+        server.stop();
+    }
+
+    @Test
+    @DisplayName("Should gzip JSON for clients that accept it and set Vary")
+    void json_WithGzipAccepted_IsCompressedWithVary() throws Exception {
+        // When
+        HttpResponse<byte[]> response = get("/cards", "gzip, deflate");
+
+        // Then
+        assertThat(response.headers().firstValue("Content-Encoding")).hasValue("gzip");
+        assertThat(response.headers().firstValue("Vary")).hasValueSatisfying(
+            vary -> assertThat(vary).containsIgnoringCase("Accept-Encoding"));
+        assertThat(gunzip(response.body())).isEqualTo(BODY);
+    }
+
+    @Test
+    @DisplayName("Should still set Vary when the client does not accept gzip")
+    void json_WithoutGzipAccepted_IsPlainWithVary() throws Exception {
+        // When
+        HttpResponse<byte[]> response = get("/cards", null);
+
+        // Then
+        assertThat(response.headers().firstValue("Content-Encoding")).isEmpty();
+        assertThat(response.headers().firstValue("Vary")).hasValueSatisfying(
+            vary -> assertThat(vary).containsIgnoringCase("Accept-Encoding"));
+        assertThat(new String(response.body(), StandardCharsets.UTF_8)).isEqualTo(BODY);
+    }
+
+    @Test
+    @DisplayName("Should not compress images or the NDJSON card stream")
+    void imagesAndStream_AreNotCompressed() throws Exception {
+        assertThat(get("/cards/1/image.png", "gzip").headers().firstValue("Content-Encoding")).isEmpty();
+        assertThat(get("/cards/stream", "gzip").headers().firstValue("Content-Encoding")).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should answer a gzip-accepting conditional card request with an empty 304 and Vary")
+    void cardById_WhenNotModified_Returns304WithVary() throws Exception {
+        // When
+        HttpResponse<byte[]> response = client.send(HttpRequest.newBuilder(uri("/cards/1"))
+            .header("Accept-Encoding", "gzip")
+            .header("If-Modified-Since", CARD_LAST_MODIFIED)
+            .build(), HttpResponse.BodyHandlers.ofByteArray());
+
+        // Then
+        assertThat(response.statusCode()).isEqualTo(304);
+        assertThat(response.body()).isEmpty();
+        assertThat(response.headers().firstValue("Content-Encoding")).isEmpty();
+        assertThat(response.headers().firstValue("Vary")).hasValueSatisfying(
+            vary -> assertThat(vary).containsIgnoringCase("Accept-Encoding"));
+    }
+
+    @Test
+    @DisplayName("Should send the full card with Last-Modified and Vary when it changed since If-Modified-Since")
+    void cardById_WhenModified_ReturnsCardWithVary() throws Exception {
+        // When
+        HttpResponse<byte[]> response = client.send(HttpRequest.newBuilder(uri("/cards/1"))
+            .header("Accept-Encoding", "gzip")
+            .header("If-Modified-Since", "Sat, 28 Feb 2026 12:00:00 GMT")
+            .build(), HttpResponse.BodyHandlers.ofByteArray());
+
+        // Then
+        assertThat(response.statusCode()).isEqualTo(200);
+        assertThat(response.headers().firstValue("Last-Modified")).hasValue(CARD_LAST_MODIFIED);
+        assertThat(response.headers().firstValue("Vary")).hasValueSatisfying(
+            vary -> assertThat(vary).containsIgnoringCase("Accept-Encoding"));
+        byte[] body = response.headers().firstValue("Content-Encoding").isPresent()
+            ? gunzip(response.body()).getBytes(StandardCharsets.UTF_8) : response.body();
+        assertThat(new String(body, StandardCharsets.UTF_8)).contains("\"name\":\"Blue-Eyes White Dragon\"");
+    }
+
+    /** The real CardController; its services are registered by {@link #cardServices}. */
+    @Configuration
+    @EnableWebMvc
+    static class CardEndpoint {
+        @Bean
+        CardController cardController() {
+            return new CardController();
+        }
+    }
+
+    /**
+     * Registered as ready-made singletons so Spring does not try to autowire
+     * the mocks' own fields. The card was last updated at CARD_LAST_MODIFIED.
+     */
+    private static BeanFactoryPostProcessor cardServices() {
+        Card card = new Card();
+        card.setId(1);
+        card.setName("Blue-Eyes White Dragon");
+        card.setUpdatedAt(LocalDateTime.of(2026, 3, 1, 12, 0));
+        CardService cardService = mock(CardService.class);
+        when(cardService.getCardById(1)).thenReturn(Optional.of(card));
+
+        return beanFactory -> {
+            beanFactory.registerSingleton("cardService", cardService);
+            beanFactory.registerSingleton("deckService", mock(DeckService.class));
+            beanFactory.registerSingleton("cardImageStore", mock(CardImageStore.class));
+            beanFactory.registerSingleton("objectMapper", new ObjectMapper().findAndRegisterModules());
+        };
+    }
+
+    private static Compression configuredCompression() throws IOException {
+        StandardEnvironment environment = new StandardEnvironment();
+        environment.getPropertySources()
+            .addLast(new ResourcePropertySource(new ClassPathResource("application.properties")));
+        return Binder.get(environment).bind("server.compression", Compression.class).get();
+    }
+
+    private static HttpResponse<byte[]> get(String path, String acceptEncoding) throws Exception {
+        HttpRequest.Builder request = HttpRequest.newBuilder(uri(path));
+        if (acceptEncoding != null) {
+            request.header("Accept-Encoding", acceptEncoding);
+        }
+        return client.send(request.build(), HttpResponse.BodyHandlers.ofByteArray());
+    }
+
+    private static URI uri(String path) {
+        return URI.create("http://localhost:" + server.getPort() + path);
+    }
+
+    private static String gunzip(byte[] body) throws IOException {
+        try (GZIPInputStream in = new GZIPInputStream(new ByteArrayInputStream(body))) {
+            return new String(in.readAllBytes(), StandardCharsets.UTF_8);
+        }
+    }
+}
@@ -0,0 +1,87 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.exception.ValidationException;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import java.util.Arrays;
+import java.util.List;
+import java.util.stream.IntStream;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("BatchIds Tests")
+class BatchIdsTest {
+
+    @Test
+    @DisplayName("Should drop duplicates and keep request order")
+    void parse_WithDuplicates_KeepsFirstOccurrences() {
+        assertThat(BatchIds.parse(List.of(3, 1, 3, 2, 1))).containsExactly(3, 1, 2);
+    }
+
+    @Test
+    @DisplayName("Should accept exactly the maximum number of IDs")
+    void parse_AtCap_IsAccepted() {
+        List<Integer> ids = IntStream.rangeClosed(1, BatchIds.MAX_IDS).boxed().toList();
+
+        assertThat(BatchIds.parse(ids)).hasSize(BatchIds.MAX_IDS);
+    }
+
+    @Test
+    @DisplayName("Should reject more IDs than the cap")
+    void parse_OverCap_ThrowsInvalidParameter() {
+        List<Integer> ids = IntStream.rangeClosed(1, BatchIds.MAX_IDS + 1).boxed().toList();
+
+        assertThatThrownBy(() -> BatchIds.parse(ids))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'ids' accepts at most 20 IDs, got 21");
+    }
+
+    @Test
+    @DisplayName("Should reject an empty ID list")
+    void parse_WithEmptyList_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> BatchIds.parse(List.of()))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'ids' must list at least one ID");
+    }
+
+    @Test
+    @DisplayName("Should reject an empty JSON body as a validation failure")
+    void parseBody_WithEmptyList_ThrowsValidation() {
+        assertThatThrownBy(() -> BatchIds.parseBody(List.of()))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body must list at least one ID");
+    }
+
+    @Test
+    @DisplayName("Should reject null entries from a JSON body as a validation failure")
+    void parseBody_WithNullId_ThrowsValidation() {
+        assertThatThrownBy(() -> BatchIds.parseBody(Arrays.asList(1, null)))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body must not contain null IDs");
+    }
+
+    @Test
+    @DisplayName("Should reject a JSON body with more IDs than the cap as a validation failure")
+    void parseBody_OverCap_ThrowsValidation() {
+        List<Integer> ids = IntStream.rangeClosed(1, BatchIds.MAX_IDS + 1).boxed().toList();
+
+        assertThatThrownBy(() -> BatchIds.parseBody(ids))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body accepts at most 20 IDs, got 21");
+    }
+
+    @Test
+    @DisplayName("Should drop duplicates from a JSON body")
+    void parseBody_WithDuplicates_KeepsFirstOccurrences() {
+        assertThat(BatchIds.parseBody(List.of(2, 2, 1))).containsExactly(2, 1);
+    }
+
+    @Test
+    @DisplayName("Should list requested IDs that were not found")
+    void missing_ReturnsUnfoundIdsInRequestOrder() {
+        assertThat(BatchIds.missing(List.of(5, 1, 9), List.of(1))).containsExactly(5, 9);
+    }
+}
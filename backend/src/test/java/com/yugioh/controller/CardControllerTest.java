@@ -1,28 +1,65 @@
 package com.yugioh.controller;
 
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.yugioh.config.DatabaseReadRetry;
+import com.yugioh.dto.DeckSummary;
 import com.yugioh.dto.PaginationResponse;
+import com.yugioh.dto.PopularCard;
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.exception.ResourceNotFoundException;
+import com.yugioh.exception.ValidationException;
 import com.yugioh.model.Card;
+import com.yugioh.repository.CardRepository;
+import com.yugioh.service.CardImageResolver;
+import com.yugioh.service.CardImageStore;
 import com.yugioh.service.CardService;
+import com.yugioh.service.DeckService;
 import org.junit.jupiter.api.BeforeEach;
 import org.junit.jupiter.api.DisplayName;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.extension.ExtendWith;
+import org.junit.jupiter.api.io.TempDir;
 import org.mockito.InjectMocks;
 import org.mockito.Mock;
+import org.mockito.Spy;
 import org.mockito.junit.jupiter.MockitoExtension;
 import org.springframework.data.domain.Page;
 import org.springframework.data.domain.PageImpl;
 import org.springframework.data.domain.PageRequest;
+import org.springframework.core.io.Resource;
+import org.springframework.http.HttpHeaders;
 import org.springframework.http.HttpStatus;
+import org.springframework.http.MediaType;
 import org.springframework.http.ResponseEntity;
-
+import org.springframework.mock.web.MockHttpServletRequest;
+import org.springframework.test.util.ReflectionTestUtils;
+import org.springframework.web.context.request.ServletWebRequest;
+import org.springframework.web.servlet.mvc.method.annotation.StreamingResponseBody;
+
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.io.OutputStream;
+import java.io.UncheckedIOException;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.time.Instant;
+import java.time.LocalDateTime;
 import java.util.Arrays;
+import java.util.LinkedHashMap;
 import java.util.List;
 import java.util.Map;
 import java.util.Optional;
+import java.util.function.Consumer;
+import java.util.stream.IntStream;
 
 import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
 import static org.mockito.ArgumentMatchers.*;
+import static org.mockito.Mockito.doAnswer;
+import static org.mockito.Mockito.mock;
+import static org.mockito.Mockito.never;
+import static org.mockito.Mockito.verify;
 import static org.mockito.Mockito.when;
 
 @ExtendWith(MockitoExtension.class)
@@ -32,6 +69,15 @@ class CardControllerTest {
     @Mock
     private CardService cardService;
 
+    @Mock
+    private DeckService deckService;
+
+    @Mock
+    private CardImageStore cardImageStore;
+
+    @Spy
+    private ObjectMapper objectMapper = new ObjectMapper().findAndRegisterModules();
+
     @InjectMocks
     private CardController cardController;
 
@@ -64,10 +110,10 @@ class CardControllerTest {
         int limit = 24;
         Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, limit), 100);
 
-        when(cardService.getAllCards(eq(page), eq(limit), isNull())).thenReturn(cardPage);
+        when(cardService.getAllCards(eq(page), eq(limit), isNull(), isNull())).thenReturn(cardPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(page, limit, null);
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(page, limit, null, null, false, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -89,10 +135,10 @@ class CardControllerTest {
         Integer firstCard = 25;
         Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, limit), 50);
 
-        when(cardService.getAllCards(eq(1), eq(limit), eq(firstCard))).thenReturn(cardPage);
+        when(cardService.getAllCards(eq(1), eq(limit), eq(firstCard), isNull())).thenReturn(cardPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, limit, firstCard);
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, limit, firstCard, null, false, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -111,10 +157,10 @@ class CardControllerTest {
         int limit = 24;
         Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, limit), 100);
 
-        when(cardService.getAllCards(eq(1), eq(limit), isNull())).thenReturn(cardPage);
+        when(cardService.getAllCards(eq(1), eq(limit), isNull(), isNull())).thenReturn(cardPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, limit, null);
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, limit, null, null, false, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -133,10 +179,10 @@ class CardControllerTest {
         Integer firstCard = 50;
         Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, limit), 50);
 
-        when(cardService.getAllCards(eq(1), eq(limit), eq(firstCard))).thenReturn(cardPage);
+        when(cardService.getAllCards(eq(1), eq(limit), eq(firstCard), isNull())).thenReturn(cardPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(page, limit, firstCard);
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(page, limit, firstCard, null, false, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -152,7 +198,8 @@ class CardControllerTest {
         when(cardService.getCardById(cardId)).thenReturn(Optional.of(testCard1));
 
         // When
-        ResponseEntity<Card> response = cardController.getCardById(cardId);
+        ResponseEntity<Card> response = cardController.getCardById(
+            cardId, new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/1")));
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -162,18 +209,53 @@ class CardControllerTest {
     }
 
     @Test
-    @DisplayName("Should return 404 when card does not exist")
-    void getCardById_WhenCardNotExists_ReturnsNotFound() {
+    @DisplayName("Should throw not found when card does not exist")
+    void getCardById_WhenCardNotExists_ThrowsNotFound() {
         // Given
         Integer cardId = 999;
         when(cardService.getCardById(cardId)).thenReturn(Optional.empty());
 
+        // When / Then
+        assertThatThrownBy(() -> cardController.getCardById(
+                cardId, new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/999"))))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Card 999 not found");
+    }
+
+    @Test
+    @DisplayName("Should send the card's updated_at as Last-Modified")
+    void getCardById_WithUpdatedAt_SetsLastModified() {
+        // Given
+        testCard1.setUpdatedAt(LocalDateTime.of(2026, 3, 1, 12, 0));
+        when(cardService.getCardById(1)).thenReturn(Optional.of(testCard1));
+
+        // When
+        ResponseEntity<Card> response = cardController.getCardById(
+            1, new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/1")));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getHeaders().getLastModified())
+            .isEqualTo(Instant.parse("2026-03-01T12:00:00Z").toEpochMilli());
+        assertThat(response.getHeaders().getVary()).containsExactly(HttpHeaders.ACCEPT_ENCODING);
+    }
+
+    @Test
+    @DisplayName("Should return 304 when the card has not changed since If-Modified-Since")
+    void getCardById_WhenNotModified_Returns304() {
+        // Given
+        testCard1.setUpdatedAt(LocalDateTime.of(2026, 3, 1, 12, 0));
+        when(cardService.getCardById(1)).thenReturn(Optional.of(testCard1));
+        MockHttpServletRequest servletRequest = new MockHttpServletRequest("GET", "/cards/1");
+        servletRequest.addHeader(HttpHeaders.IF_MODIFIED_SINCE, Instant.parse("2026-03-01T12:00:00Z").toEpochMilli());
+
         // When
-        ResponseEntity<Card> response = cardController.getCardById(cardId);
+        ResponseEntity<Card> response = cardController.getCardById(1, new ServletWebRequest(servletRequest));
 
         // Then
-        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NOT_FOUND);
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NOT_MODIFIED);
         assertThat(response.getBody()).isNull();
+        assertThat(response.getHeaders().getVary()).containsExactly(HttpHeaders.ACCEPT_ENCODING);
     }
 
     @Test
@@ -184,10 +266,10 @@ class CardControllerTest {
         Integer invalidFirstCard = 0;
         Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, limit), 100);
 
-        when(cardService.getAllCards(eq(1), eq(limit), isNull())).thenReturn(cardPage);
+        when(cardService.getAllCards(eq(1), eq(limit), isNull(), isNull())).thenReturn(cardPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, limit, invalidFirstCard);
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, limit, invalidFirstCard, null, false, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -203,14 +285,520 @@ class CardControllerTest {
         int limit = 24;
         Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, limit), 100);
 
-        when(cardService.getAllCards(eq(1), eq(limit), isNull())).thenReturn(cardPage);
+        when(cardService.getAllCards(eq(1), eq(limit), isNull(), isNull())).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(invalidPage, limit, null, null, false, null, false);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getPage()).isEqualTo(1);
+    }
+
+    @Test
+    @DisplayName("Should get card usage with decks and characters")
+    void getCardUsage_WhenCardExists_ReturnsDecksAndCharacters() {
+        // Given
+        Integer cardId = 1;
+        int limit = 20;
+        DeckSummary deck = new DeckSummary(1, "Kaiba's Deck", "Kaiba's main deck", "Seto Kaiba",
+                                        "Blue-Eyes", "Light", 100, 98, 40, true);
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(deck), PageRequest.of(0, limit), 1);
+
+        when(cardService.getCardById(cardId)).thenReturn(Optional.of(testCard1));
+        when(deckService.getDecksUsingCard(cardId, 1, limit)).thenReturn(deckPage);
+        when(deckService.getCharactersUsingCard(cardId)).thenReturn(List.of("Seto Kaiba"));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getCardUsage(cardId, null, limit);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody()).isNotNull();
+        assertThat(response.getBody().get("cardId")).isEqualTo(cardId);
+        assertThat(response.getBody().get("deckCount")).isEqualTo(1L);
+        assertThat(response.getBody().get("characterCount")).isEqualTo(1);
+        assertThat(response.getBody().get("characters")).isEqualTo(List.of("Seto Kaiba"));
+        assertThat(response.getBody().get("decks")).isEqualTo(List.of(deck));
+
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getPage()).isEqualTo(1);
+        assertThat(pagination.getTotal()).isEqualTo(1L);
+    }
+
+    @Test
+    @DisplayName("Should paginate card usage with page parameter")
+    void getCardUsage_WithPage_PassesPageToService() {
+        // Given
+        Integer cardId = 1;
+        int page = 2;
+        int limit = 20;
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(), PageRequest.of(1, limit), 25);
+
+        when(cardService.getCardById(cardId)).thenReturn(Optional.of(testCard1));
+        when(deckService.getDecksUsingCard(cardId, page, limit)).thenReturn(deckPage);
+        when(deckService.getCharactersUsingCard(cardId)).thenReturn(List.of());
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getCardUsage(cardId, page, limit);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getPage()).isEqualTo(2);
+        assertThat(pagination.getTotalPages()).isEqualTo(2);
+    }
+
+    @Test
+    @DisplayName("Should default card usage to page 1 when page is invalid")
+    void getCardUsage_WithInvalidPage_DefaultsToPageOne() {
+        // Given
+        Integer cardId = 1;
+        int limit = 20;
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(), PageRequest.of(0, limit), 0);
+
+        when(cardService.getCardById(cardId)).thenReturn(Optional.of(testCard1));
+        when(deckService.getDecksUsingCard(cardId, 1, limit)).thenReturn(deckPage);
+        when(deckService.getCharactersUsingCard(cardId)).thenReturn(List.of());
 
         // When
-        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(invalidPage, limit, null);
+        ResponseEntity<Map<String, Object>> response = cardController.getCardUsage(cardId, 0, limit);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
         PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
         assertThat(pagination.getPage()).isEqualTo(1);
     }
+
+    @Test
+    @DisplayName("Should throw not found for usage of a card that does not exist")
+    void getCardUsage_WhenCardNotExists_ThrowsNotFound() {
+        // Given
+        Integer cardId = 999;
+        when(cardService.getCardById(cardId)).thenReturn(Optional.empty());
+
+        // When / Then
+        assertThatThrownBy(() -> cardController.getCardUsage(cardId, null, 20))
+            .isInstanceOf(ResourceNotFoundException.class);
+        verify(deckService, never()).getDecksUsingCard(anyInt(), anyInt(), anyInt());
+    }
+
+    @Test
+    @DisplayName("Should stream cards as newline-delimited JSON")
+    void streamCards_WritesOneCardPerLine() throws IOException {
+        // Given
+        doAnswer(invocation -> {
+            Consumer<Card> consumer = invocation.getArgument(0);
+            testCards.forEach(consumer);
+            return null;
+        }).when(cardService).streamAllCards(any());
+
+        // When
+        ResponseEntity<StreamingResponseBody> response = cardController.streamCards();
+        ByteArrayOutputStream out = new ByteArrayOutputStream();
+        response.getBody().writeTo(out);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getHeaders().getContentType()).isEqualTo(CardController.APPLICATION_NDJSON);
+        String[] lines = out.toString(StandardCharsets.UTF_8).split("\n");
+        assertThat(lines).hasSize(2);
+        assertThat(lines[0]).contains("\"name\":\"Blue-Eyes White Dragon\"");
+        assertThat(lines[1]).contains("\"name\":\"Dark Magician\"");
+    }
+
+    @Test
+    @DisplayName("Should abort the card stream when the client disconnects")
+    void streamCards_WhenClientDisconnects_Aborts() {
+        // Given
+        doAnswer(invocation -> {
+            Consumer<Card> consumer = invocation.getArgument(0);
+            testCards.forEach(consumer);
+            return null;
+        }).when(cardService).streamAllCards(any());
+        OutputStream disconnected = new OutputStream() {
+            @Override
+            public void write(int b) throws IOException {
+                throw new IOException("Broken pipe");
+            }
+        };
+
+        // When
+        StreamingResponseBody body = cardController.streamCards().getBody();
+
+        // Then
+        assertThatThrownBy(() -> body.writeTo(disconnected))
+            .isInstanceOf(UncheckedIOException.class)
+            .hasRootCauseMessage("Broken pipe");
+    }
+
+    @Test
+    @DisplayName("Should return batch cards in request order from the ids query")
+    void getCardsByIds_WithQueryIds_ReturnsCardsInRequestOrder() {
+        // Given
+        when(cardService.getCardsByIds(List.of(2, 1, 99))).thenReturn(List.of(testCard1, testCard2));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getCardsByIds(List.of(2, 1, 99, 2));
+
+        // Then
+        assertThat(response.getBody().get("cards")).isEqualTo(List.of(testCard2, testCard1));
+        assertThat(response.getBody().get("missing")).isEqualTo(List.of(99));
+    }
+
+    @Test
+    @DisplayName("Should accept batch card IDs as a JSON array body")
+    void postCardsByIds_WithJsonArray_ReturnsCards() {
+        // Given
+        when(cardService.getCardsByIds(List.of(1))).thenReturn(List.of(testCard1));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.postCardsByIds(List.of(1));
+
+        // Then
+        assertThat(response.getBody().get("cards")).isEqualTo(List.of(testCard1));
+        assertThat(response.getBody().get("missing")).isEqualTo(List.of());
+    }
+
+    @Test
+    @DisplayName("Should reject an empty ids query parameter as an invalid parameter")
+    void getCardsByIds_WithEmptyIds_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> cardController.getCardsByIds(List.of()))
+            .isInstanceOf(InvalidParameterException.class);
+        verify(cardService, never()).getCardsByIds(any());
+    }
+
+    @Test
+    @DisplayName("Should reject an empty or oversized JSON body as a validation failure")
+    void postCardsByIds_WithInvalidBody_ThrowsValidation() {
+        List<Integer> tooMany = IntStream.rangeClosed(1, BatchIds.MAX_IDS + 1).boxed().toList();
+
+        assertThatThrownBy(() -> cardController.postCardsByIds(List.of()))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body must list at least one ID");
+        assertThatThrownBy(() -> cardController.postCardsByIds(tooMany))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body accepts at most 20 IDs, got 21");
+        verify(cardService, never()).getCardsByIds(any());
+    }
+
+    @Test
+    @DisplayName("Should serialize empty batch and usage results as arrays, not null")
+    void emptyResults_SerializeAsEmptyArrays() throws Exception {
+        // Given
+        when(cardService.getCardsByIds(List.of(99))).thenReturn(List.of());
+        when(cardService.getCardById(1)).thenReturn(Optional.of(testCard1));
+        when(deckService.getDecksUsingCard(1, 1, 20)).thenReturn(Page.empty(PageRequest.of(0, 20)));
+        when(deckService.getCharactersUsingCard(1)).thenReturn(List.of());
+
+        // When
+        String batch = objectMapper.writeValueAsString(cardController.getCardsByIds(List.of(99)).getBody());
+        String usage = objectMapper.writeValueAsString(cardController.getCardUsage(1, null, 20).getBody());
+
+        // Then
+        assertThat(batch).contains("\"cards\":[]");
+        assertThat(usage).contains("\"decks\":[]").contains("\"characters\":[]");
+    }
+
+    @Test
+    @DisplayName("Should return similar cards for an existing card")
+    void getSimilarCards_WhenCardExists_ReturnsRecommendations() {
+        // Given
+        when(cardService.getCardById(1)).thenReturn(Optional.of(testCard1));
+        when(cardService.getSimilarCards(testCard1, 5)).thenReturn(List.of(testCard2));
+
+        // When
+        ResponseEntity<List<Card>> response = cardController.getSimilarCards(1, 5);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody()).containsExactly(testCard2);
+    }
+
+    @Test
+    @DisplayName("Should throw not found for similar cards of a missing card")
+    void getSimilarCards_WhenCardNotExists_ThrowsNotFound() {
+        when(cardService.getCardById(999)).thenReturn(Optional.empty());
+
+        assertThatThrownBy(() -> cardController.getSimilarCards(999, 10))
+            .isInstanceOf(ResourceNotFoundException.class);
+        verify(cardService, never()).getSimilarCards(any(), anyInt());
+    }
+
+    @Test
+    @DisplayName("Should pass the excluded deck through to the service")
+    void getAllCards_WithExcludeDeck_PassesDeckId() {
+        // Given
+        Page<Card> cardPage = new PageImpl<>(List.of(testCard2), PageRequest.of(0, 24), 1);
+        when(cardService.getAllCards(1, 24, null, 5)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, 24, null, 5, false, null, false);
+
+        // Then
+        assertThat(response.getBody().get("cards")).isEqualTo(List.of(testCard2));
+    }
+
+    @Test
+    @DisplayName("Should echo the applied parameters")
+    void getAllCards_EchoesAppliedParameters() {
+        // Given: firstCard takes precedence, so page is reset to 1
+        Page<Card> cardPage = new PageImpl<>(List.of(testCard2), PageRequest.of(0, 24), 1);
+        when(cardService.getAllCards(1, 24, 2, 5)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(3, 24, 2, 5, false, null, false);
+
+        // Then
+        assertThat(response.getBody().get("applied")).isEqualTo(Map.of(
+            "page", 1, "limit", 24, "firstCard", 2, "exclude_deck", 5, "include_facets", false,
+            "ignored", List.of("page")));
+    }
+
+    @Test
+    @DisplayName("Should report an out-of-range page and firstCard as ignored")
+    void getAllCards_WithInvalidPageAndFirstCard_ReportsIgnored() {
+        // Given
+        Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, 24), 100);
+        when(cardService.getAllCards(1, 24, null, null)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(-2, 24, 0, null, false, null, false);
+
+        // Then
+        @SuppressWarnings("unchecked")
+        Map<String, Object> applied = (Map<String, Object>) response.getBody().get("applied");
+        assertThat(applied.get("ignored")).isEqualTo(List.of("page", "firstCard"));
+    }
+
+    @Test
+    @DisplayName("Should return just the card count for the listing filters")
+    void countCards_ReturnsTotal() {
+        // Given
+        when(cardService.countCards(10, 5)).thenReturn(612L);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.countCards(10, 5);
+
+        // Then
+        assertThat(response.getBody()).isEqualTo(Map.of("total", 612L));
+    }
+
+    @Test
+    @DisplayName("Should search cards and echo the applied parameters")
+    void searchCards_ReturnsMatchesWithApplied() {
+        // Given
+        Page<Card> cardPage = new PageImpl<>(List.of(testCard2), PageRequest.of(0, 24), 1);
+        when(cardService.searchCards(" destroy ", List.of("name", "description"), 1, 24)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response =
+            cardController.searchCards(" destroy ", List.of("name", " description", "name"), null, 24, false);
+
+        // Then
+        assertThat(response.getBody().get("cards")).isEqualTo(List.of(testCard2));
+        assertThat(response.getBody().get("applied")).isEqualTo(Map.of(
+            "q", "destroy", "fields", List.of("name", "description"), "page", 1, "limit", 24));
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getTotal()).isEqualTo(1L);
+    }
+
+    @Test
+    @DisplayName("Should list popular cards with deck counts inlined")
+    void getPopularCards_ReturnsCardsWithDeckCounts() throws Exception {
+        // Given
+        Page<PopularCard> cardPage = new PageImpl<>(List.of(new PopularCard(testCard1, 4L)), PageRequest.of(0, 24), 1);
+        when(cardService.getPopularCards(1, 24, false)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getPopularCards(0, 24, false);
+        String json = objectMapper.writeValueAsString(response.getBody().get("cards"));
+
+        // Then
+        assertThat(json).contains("\"id\":1").contains("\"name\":\"Blue-Eyes White Dragon\"")
+            .contains("\"deckCount\":4").doesNotContain("\"card\"");
+        assertThat(response.getBody().get("applied")).isEqualTo(Map.of(
+            "page", 1, "limit", 24, "preset", false, "ignored", List.of("page")));
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getTotal()).isEqualTo(1L);
+    }
+
+    @Test
+    @DisplayName("Should reject a limit outside 1..100")
+    void getAllCards_WithLimitOutOfRange_Throws() {
+        assertThatThrownBy(() -> cardController.getAllCards(1, 0, null, null, false, null, false))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'limit' must be between 1 and 100, got 0");
+        assertThatThrownBy(() -> cardController.getAllCards(1, 101, null, null, false, null, false))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'limit' must be between 1 and 100, got 101");
+    }
+
+    @Test
+    @DisplayName("Should leave unrequested fields out of the card JSON")
+    void getAllCards_WithFields_OmitsOtherFields() throws Exception {
+        // Given
+        List<String> fields = List.of("id", "name", "image", "type");
+        Map<String, Object> row = new LinkedHashMap<>();
+        row.put("id", 1);
+        row.put("name", "Blue-Eyes White Dragon");
+        row.put("image", "https://example.com/placeholder.png");
+        row.put("type", "Normal Monster");
+        Page<Map<String, Object>> cardPage = new PageImpl<>(List.of(row), PageRequest.of(0, 24), 1);
+        when(cardService.getAllCardFields(1, 24, null, null, fields)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response =
+            cardController.getAllCards(null, 24, null, null, false, fields, false);
+        String json = objectMapper.writeValueAsString(response.getBody().get("cards"));
+
+        // Then
+        assertThat(json).contains("\"id\":1", "\"name\":", "\"image\":", "\"type\":")
+            .doesNotContain("description", "attackPoints", "defensePoints", "rarity", "cost");
+        verify(cardService, never()).getAllCards(anyInt(), anyInt(), any(), any());
+        @SuppressWarnings("unchecked")
+        Map<String, Object> applied = (Map<String, Object>) response.getBody().get("applied");
+        assertThat(applied.get("fields")).isEqualTo(List.of("id", "name", "image", "type"));
+    }
+
+    @Test
+    @DisplayName("Should attach facets when requested")
+    void getAllCards_WithIncludeFacets_AddsFacets() {
+        // Given
+        Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, 24), 2);
+        Map<String, Object> facets = Map.of("total", 2L, "type", Map.of("Monster", 2L));
+        when(cardService.getAllCards(1, 24, null, 5)).thenReturn(cardPage);
+        when(cardService.getCardFacets(null, 5)).thenReturn(facets);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, 24, null, 5, true, null, false);
+
+        // Then
+        assertThat(response.getBody().get("facets")).isEqualTo(facets);
+    }
+
+    @Test
+    @DisplayName("Should omit facets by default")
+    void getAllCards_WithoutIncludeFacets_OmitsFacets() {
+        // Given
+        Page<Card> cardPage = new PageImpl<>(testCards, PageRequest.of(0, 24), 2);
+        when(cardService.getAllCards(1, 24, null, null)).thenReturn(cardPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = cardController.getAllCards(null, 24, null, null, false, null, false);
+
+        // Then
+        assertThat(response.getBody()).doesNotContainKey("facets");
+        verify(cardService, never()).getCardFacets(any(), any());
+    }
+
+    @Test
+    @DisplayName("Should serve the card image with content type and caching headers")
+    void getCardImage_WhenFileExists_ReturnsImage(@TempDir Path imageDir) throws IOException {
+        // Given
+        Path file = Files.write(imageDir.resolve("1.jpg"), new byte[]{1, 2, 3});
+        when(cardService.getStoredImage(1)).thenReturn(Optional.of("1.jpg"));
+        when(cardImageStore.find("1.jpg")).thenReturn(Optional.of(file));
+        ServletWebRequest request = new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/1/image"));
+
+        // When
+        ResponseEntity<Resource> response = cardController.getCardImage(1, request);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getHeaders().getContentType()).isEqualTo(MediaType.IMAGE_JPEG);
+        assertThat(response.getHeaders().getCacheControl()).isEqualTo("max-age=604800, public");
+        assertThat(response.getHeaders().getLastModified()).isPositive();
+        assertThat(response.getBody().getContentAsByteArray()).containsExactly(1, 2, 3);
+    }
+
+    @Test
+    @DisplayName("Should fall back to octet-stream for unknown image extensions")
+    void getCardImage_WithUnknownExtension_ReturnsOctetStream(@TempDir Path imageDir) throws IOException {
+        // Given
+        Path file = Files.write(imageDir.resolve("1.unknownext"), new byte[]{1});
+        when(cardService.getStoredImage(1)).thenReturn(Optional.of("1.jpg"));
+        when(cardImageStore.find(any())).thenReturn(Optional.of(file));
+        ServletWebRequest request = new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/1/image"));
+
+        // When
+        ResponseEntity<Resource> response = cardController.getCardImage(1, request);
+
+        // Then
+        assertThat(response.getHeaders().getContentType()).isEqualTo(MediaType.APPLICATION_OCTET_STREAM);
+    }
+
+    @Test
+    @DisplayName("Should return 304 when the client's copy is current")
+    void getCardImage_WhenNotModified_Returns304(@TempDir Path imageDir) throws IOException {
+        // Given
+        Path file = Files.write(imageDir.resolve("1.png"), new byte[]{1});
+        when(cardService.getStoredImage(1)).thenReturn(Optional.of("1.jpg"));
+        when(cardImageStore.find(any())).thenReturn(Optional.of(file));
+        MockHttpServletRequest servletRequest = new MockHttpServletRequest("GET", "/cards/1/image");
+        servletRequest.addHeader(HttpHeaders.IF_MODIFIED_SINCE, file.toFile().lastModified() + 60_000);
+
+        // When
+        ResponseEntity<Resource> response = cardController.getCardImage(1, new ServletWebRequest(servletRequest));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NOT_MODIFIED);
+        assertThat(response.getBody()).isNull();
+    }
+
+    @Test
+    @DisplayName("Should throw not found when the card has no image file")
+    void getCardImage_WhenFileMissing_ThrowsNotFound() {
+        // Given
+        when(cardService.getStoredImage(1)).thenReturn(Optional.of("1.jpg"));
+        when(cardImageStore.find(any())).thenReturn(Optional.empty());
+        ServletWebRequest request = new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/1/image"));
+
+        // When / Then
+        assertThatThrownBy(() -> cardController.getCardImage(1, request))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Card image 1 not found");
+    }
+
+    @Test
+    @DisplayName("Should throw not found when the card does not exist")
+    void getCardImage_WhenCardMissing_ThrowsNotFound() {
+        // Given
+        when(cardService.getStoredImage(99)).thenReturn(Optional.empty());
+        ServletWebRequest request = new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/99/image"));
+
+        // When / Then
+        assertThatThrownBy(() -> cardController.getCardImage(99, request))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Card 99 not found");
+        verify(cardImageStore, never()).find(any());
+    }
+
+    @Test
+    @DisplayName("Should serve the stored image file even though the card's image resolves to a URL")
+    void getCardImage_WithRealResolver_FindsStoredFile(@TempDir Path imageDir) throws IOException {
+        // Given
+        Files.write(imageDir.resolve("1.jpg"), new byte[]{1, 2, 3});
+        testCard1.setImage("1.jpg");
+        CardRepository cardRepository = mock(CardRepository.class);
+        when(cardRepository.findById(1)).thenReturn(Optional.of(testCard1));
+        CardService realCardService = new CardService();
+        ReflectionTestUtils.setField(realCardService, "cardRepository", cardRepository);
+        ReflectionTestUtils.setField(realCardService, "cardImageResolver",
+            new CardImageResolver("", "https://example.com/placeholder.png"));
+        ReflectionTestUtils.setField(realCardService, "databaseReadRetry", new DatabaseReadRetry(0));
+        CardController controller = new CardController();
+        ReflectionTestUtils.setField(controller, "cardService", realCardService);
+        ReflectionTestUtils.setField(controller, "cardImageStore", new CardImageStore(imageDir.toString()));
+        ServletWebRequest request = new ServletWebRequest(new MockHttpServletRequest("GET", "/cards/1/image"));
+
+        // When
+        ResponseEntity<Resource> response = controller.getCardImage(1, request);
+
+        // Then
+        assertThat(realCardService.getCardById(1).get().getImage()).isEqualTo("https://example.com/placeholder.png");
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody().getContentAsByteArray()).containsExactly(1, 2, 3);
+    }
 }
@@ -0,0 +1,37 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.InvalidParameterException;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import java.util.List;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("CardFields Tests")
+class CardFieldsTest {
+
+    @Test
+    @DisplayName("Should trim field names and drop duplicates")
+    void parse_WithDuplicates_KeepsFirstOccurrences() {
+        assertThat(CardFields.parse(List.of("id", " name", "id", "type "))).containsExactly("id", "name", "type");
+    }
+
+    @Test
+    @DisplayName("Should reject unknown field names")
+    void parse_WithUnknownField_Throws() {
+        assertThatThrownBy(() -> CardFields.parse(List.of("id", "power")))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessageStartingWith("Parameter 'fields' must only contain [id, name,")
+            .hasMessageEndingWith("got 'power'");
+    }
+
+    @Test
+    @DisplayName("Should reject an empty field list")
+    void parse_WithBlankFields_Throws() {
+        assertThatThrownBy(() -> CardFields.parse(List.of(" ")))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'fields' must list at least one field");
+    }
+}
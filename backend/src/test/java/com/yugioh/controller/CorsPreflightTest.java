@@ -0,0 +1,57 @@
+package com.yugioh.controller;
+
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.test.web.servlet.MockMvc;
+import org.springframework.test.web.servlet.setup.MockMvcBuilders;
+
+import java.time.Clock;
+
+import static org.springframework.test.web.servlet.request.MockMvcRequestBuilders.options;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.header;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.status;
+
+/**
+ * Preflight responses are derived from each handler's mapping by @CrossOrigin,
+ * so read-only endpoints only advertise the methods they actually support.
+ */
+@DisplayName("CORS Preflight Tests")
+class CorsPreflightTest {
+
+    private MockMvc mockMvc;
+
+    @BeforeEach
+    void setUp() {
+        mockMvc = MockMvcBuilders
+            .standaloneSetup(new CardController(), new DeckController(), new HealthController(Clock.systemUTC()))
+            .build();
+    }
+
+    @Test
+    @DisplayName("Should advertise only GET for read-only card endpoints")
+    void preflight_CardsGet_AllowsOnlyGet() throws Exception {
+        mockMvc.perform(options("/cards/1")
+                .header("Origin", "http://localhost:8082")
+                .header("Access-Control-Request-Method", "GET"))
+            .andExpect(status().isOk())
+            .andExpect(header().string("Access-Control-Allow-Methods", "GET"));
+    }
+
+    @Test
+    @DisplayName("Should not grant CORS for a method the endpoint does not support")
+    void preflight_DecksDelete_IsNotAllowed() throws Exception {
+        mockMvc.perform(options("/decks")
+                .header("Origin", "http://localhost:8082")
+                .header("Access-Control-Request-Method", "DELETE"))
+            .andExpect(header().doesNotExist("Access-Control-Allow-Origin"));
+    }
+
+    @Test
+    @DisplayName("Should report registered methods in Allow header for plain OPTIONS")
+    void options_Healthcheck_ReportsAllowHeader() throws Exception {
+        mockMvc.perform(options("/healthcheck"))
+            .andExpect(status().isOk())
+            .andExpect(header().string("Allow", "GET,HEAD,OPTIONS"));
+    }
+}
@@ -1,9 +1,17 @@
 package com.yugioh.controller;
 
+import com.yugioh.config.DeckCloneLimit;
 import com.yugioh.dto.DeckSummary;
+import com.yugioh.dto.DeckUpdateRequest;
 import com.yugioh.dto.DeckWithCards;
 import com.yugioh.dto.PaginationResponse;
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.model.Card;
+import com.yugioh.exception.RateLimitedException;
+import com.yugioh.exception.ResourceNotFoundException;
+import com.yugioh.exception.ValidationException;
 import com.yugioh.service.DeckService;
+import org.junit.jupiter.api.AfterEach;
 import org.junit.jupiter.api.BeforeEach;
 import org.junit.jupiter.api.DisplayName;
 import org.junit.jupiter.api.Test;
@@ -16,14 +24,21 @@ import org.springframework.data.domain.PageImpl;
 import org.springframework.data.domain.PageRequest;
 import org.springframework.http.HttpStatus;
 import org.springframework.http.ResponseEntity;
+import org.springframework.mock.web.MockHttpServletRequest;
+import org.springframework.web.context.request.RequestContextHolder;
+import org.springframework.web.context.request.ServletRequestAttributes;
 
 import java.util.Arrays;
 import java.util.List;
 import java.util.Map;
 import java.util.Optional;
+import java.util.stream.IntStream;
 
 import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
 import static org.mockito.ArgumentMatchers.*;
+import static org.mockito.Mockito.doThrow;
+import static org.mockito.Mockito.never;
 import static org.mockito.Mockito.verify;
 import static org.mockito.Mockito.when;
 
@@ -34,6 +49,9 @@ class DeckControllerTest {
     @Mock
     private DeckService deckService;
 
+    @Mock
+    private DeckCloneLimit deckCloneLimit;
+
     @InjectMocks
     private DeckController deckController;
 
@@ -50,6 +68,11 @@ class DeckControllerTest {
         testDecks = Arrays.asList(testDeck1, testDeck2);
     }
 
+    @AfterEach
+    void tearDown() {
+        RequestContextHolder.resetRequestAttributes();
+    }
+
     @Test
     @DisplayName("Should get all decks with page parameter")
     void getAllDecks_WithPage_ReturnsPaginatedDecks() {
@@ -58,10 +81,10 @@ class DeckControllerTest {
         int limit = 20;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), isNull())).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, null, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, null, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -84,13 +107,13 @@ class DeckControllerTest {
         int calculatedPage = 1;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), isNull(), eq(false)))
+        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), isNull(), eq(false), isNull(), isNull()))
             .thenReturn(calculatedPage);
-        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), isNull(), isNull()))
+        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull()))
             .thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, firstDeck, null, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, firstDeck, null, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -105,10 +128,10 @@ class DeckControllerTest {
         int limit = 20;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.getAllDecks(eq(1), eq(limit), isNull(), isNull())).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(1), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, null, null, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, null, null, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -126,13 +149,13 @@ class DeckControllerTest {
         int calculatedPage = 1;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), isNull(), eq(false)))
+        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), isNull(), eq(false), isNull(), isNull()))
             .thenReturn(calculatedPage);
-        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), isNull(), isNull()))
+        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull()))
             .thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, firstDeck, null, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, firstDeck, null, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -140,6 +163,30 @@ class DeckControllerTest {
         assertThat(pagination.getPage()).isEqualTo(calculatedPage);
     }
 
+    @Test
+    @DisplayName("Should ignore firstDeck when the list is sorted by cost")
+    @SuppressWarnings("unchecked")
+    void getAllDecks_WithFirstDeckAndSort_IgnoresFirstDeck() {
+        // Given
+        int page = 2;
+        int limit = 20;
+        Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(1, limit), 50);
+
+        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), isNull(), eq("-cost"), isNull(), isNull()))
+            .thenReturn(deckPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, 10, null, null, "-cost", null, null, false);
+
+        // Then
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getPage()).isEqualTo(page);
+        Map<String, Object> applied = (Map<String, Object>) response.getBody().get("applied");
+        assertThat(applied).doesNotContainKey("firstDeck");
+        assertThat(applied.get("ignored")).isEqualTo(List.of("firstDeck"));
+        verify(deckService, never()).calculatePageFromDeckId(any(), anyInt(), any(), anyBoolean(), any(), any());
+    }
+
     @Test
     @DisplayName("Should filter decks by archetype")
     void getAllDecks_WithArchetype_ReturnsFilteredDecks() {
@@ -149,10 +196,10 @@ class DeckControllerTest {
         String archetype = "Dark Magician";
         Page<DeckSummary> deckPage = new PageImpl<>(Arrays.asList(testDeck1), PageRequest.of(0, limit), 10);
 
-        when(deckService.getAllDecks(eq(page), eq(limit), eq(archetype), isNull())).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(page), eq(limit), eq(archetype), isNull(), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, archetype, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, archetype, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -172,10 +219,10 @@ class DeckControllerTest {
         Boolean preset = true;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 30);
 
-        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), eq(true))).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), eq(true), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, null, preset);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, null, preset, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -207,18 +254,96 @@ class DeckControllerTest {
     }
 
     @Test
-    @DisplayName("Should return 404 when deck does not exist")
-    void getDeckById_WhenDeckNotExists_ReturnsNotFound() {
+    @DisplayName("Should throw not found when deck does not exist")
+    void getDeckById_WhenDeckNotExists_ThrowsNotFound() {
         // Given
         Integer deckId = 999;
         when(deckService.getDeckById(deckId)).thenReturn(Optional.empty());
 
+        // When / Then
+        assertThatThrownBy(() -> deckController.getDeckById(deckId))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Deck 999 not found");
+    }
+
+    @Test
+    @DisplayName("Should return just the card IDs by default")
+    void getDeckCards_WithIdsDetail_ReturnsCardIds() {
+        // Given
+        when(deckService.getDeckCardIds(1)).thenReturn(Optional.of(List.of(3, 1, 1)));
+
         // When
-        ResponseEntity<DeckWithCards> response = deckController.getDeckById(deckId);
+        ResponseEntity<List<?>> response = deckController.getDeckCards(1, "ids");
 
         // Then
-        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NOT_FOUND);
-        assertThat(response.getBody()).isNull();
+        assertThat(response.getBody()).isEqualTo(List.of(3, 1, 1));
+        verify(deckService, never()).getDeckById(any());
+    }
+
+    @Test
+    @DisplayName("Should return the full cards with detail=full")
+    void getDeckCards_WithFullDetail_ReturnsCards() {
+        // Given
+        Card card = new Card();
+        card.setId(3);
+        DeckWithCards deck = new DeckWithCards();
+        deck.setId(1);
+        deck.setCards(List.of(card));
+        when(deckService.getDeckById(1)).thenReturn(Optional.of(deck));
+
+        // When
+        ResponseEntity<List<?>> response = deckController.getDeckCards(1, "full");
+
+        // Then
+        assertThat(response.getBody()).containsExactly(card);
+    }
+
+    @Test
+    @DisplayName("Should throw not found for the card IDs of a missing deck")
+    void getDeckCards_WhenDeckNotExists_ThrowsNotFound() {
+        when(deckService.getDeckCardIds(999)).thenReturn(Optional.empty());
+
+        assertThatThrownBy(() -> deckController.getDeckCards(999, "ids"))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Deck 999 not found");
+    }
+
+    @Test
+    @DisplayName("Should reject an unsupported detail value")
+    void getDeckCards_WithUnknownDetail_Throws() {
+        assertThatThrownBy(() -> deckController.getDeckCards(1, "names"))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'detail' must be one of [ids, full], got 'names'");
+    }
+
+    @Test
+    @DisplayName("Should return the updated deck")
+    void updateDeck_WhenDeckExists_ReturnsDeck() {
+        // Given
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName("Renamed");
+        DeckWithCards deck = new DeckWithCards();
+        deck.setId(5);
+        deck.setName("Renamed");
+        when(deckService.updateDeck(5, update)).thenReturn(Optional.of(deck));
+
+        // When
+        ResponseEntity<DeckWithCards> response = deckController.updateDeck(5, update);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody().getName()).isEqualTo("Renamed");
+    }
+
+    @Test
+    @DisplayName("Should throw not found when updating a missing deck")
+    void updateDeck_WhenDeckNotExists_ThrowsNotFound() {
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        when(deckService.updateDeck(999, update)).thenReturn(Optional.empty());
+
+        assertThatThrownBy(() -> deckController.updateDeck(999, update))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Deck 999 not found");
     }
 
     @Test
@@ -229,10 +354,10 @@ class DeckControllerTest {
         Integer invalidFirstDeck = 0;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.getAllDecks(eq(1), eq(limit), isNull(), isNull())).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(1), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, invalidFirstDeck, null, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, invalidFirstDeck, null, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -248,10 +373,10 @@ class DeckControllerTest {
         int limit = 20;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.getAllDecks(eq(1), eq(limit), isNull(), isNull())).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(1), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(invalidPage, limit, null, null, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(invalidPage, limit, null, null, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -269,13 +394,13 @@ class DeckControllerTest {
         int calculatedPage = 2;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(1, limit), 50);
 
-        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), isNull(), eq(true)))
+        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), isNull(), eq(true), isNull(), isNull()))
             .thenReturn(calculatedPage);
-        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), isNull(), eq(true)))
+        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), isNull(), eq(true), isNull(), isNull(), isNull()))
             .thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, firstDeck, null, preset);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, firstDeck, null, preset, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
@@ -292,14 +417,14 @@ class DeckControllerTest {
         Boolean preset = false;
         Page<DeckSummary> deckPage = new PageImpl<>(testDecks, PageRequest.of(0, limit), 50);
 
-        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), isNull())).thenReturn(deckPage);
+        when(deckService.getAllDecks(eq(page), eq(limit), isNull(), isNull(), isNull(), isNull(), isNull())).thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, null, preset);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(page, limit, null, null, preset, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
-        verify(deckService).getAllDecks(eq(page), eq(limit), isNull(), isNull());
+        verify(deckService).getAllDecks(eq(page), eq(limit), isNull(), isNull(), isNull());
     }
 
     @Test
@@ -312,17 +437,292 @@ class DeckControllerTest {
         int calculatedPage = 1;
         Page<DeckSummary> deckPage = new PageImpl<>(Arrays.asList(testDeck1), PageRequest.of(0, limit), 10);
 
-        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), eq(archetype), eq(false)))
+        when(deckService.calculatePageFromDeckId(eq(firstDeck), eq(limit), eq(archetype), eq(false), isNull(), isNull()))
             .thenReturn(calculatedPage);
-        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), eq(archetype), isNull()))
+        when(deckService.getAllDecks(eq(calculatedPage), eq(limit), eq(archetype), isNull(), isNull(), isNull(), isNull()))
             .thenReturn(deckPage);
 
         // When
-        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, firstDeck, archetype, null);
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, limit, firstDeck, archetype, null, null, null, null, false);
 
         // Then
         assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
         PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
         assertThat(pagination.getPage()).isEqualTo(calculatedPage);
     }
+
+    @Test
+    @DisplayName("Should return 201 with the cloned deck")
+    void cloneDeck_WhenSourceExists_ReturnsCreated() {
+        // Given
+        DeckWithCards clone = new DeckWithCards();
+        clone.setId(5);
+        clone.setName("Yugi's Deck (Copy)");
+        clone.setIsPreset(false);
+
+        when(deckService.cloneDeck(1)).thenReturn(Optional.of(clone));
+
+        // When
+        ResponseEntity<DeckWithCards> response = deckController.cloneDeck(1);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.CREATED);
+        assertThat(response.getHeaders().getLocation()).hasToString("/decks/5");
+        assertThat(response.getBody()).isSameAs(clone);
+    }
+
+    @Test
+    @DisplayName("Should throw not found when cloning a deck that does not exist")
+    void cloneDeck_WhenSourceNotExists_ThrowsNotFound() {
+        // Given
+        when(deckService.cloneDeck(999)).thenReturn(Optional.empty());
+
+        // When / Then
+        assertThatThrownBy(() -> deckController.cloneDeck(999))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Deck 999 not found");
+    }
+
+    @Test
+    @DisplayName("Should not clone when the clone limit refuses")
+    void cloneDeck_WhenLimitRefuses_DoesNotClone() {
+        // Given
+        doThrow(new RateLimitedException("At most 10 decks can be cloned per minute, try again later"))
+            .when(deckCloneLimit).acquire();
+
+        // When / Then
+        assertThatThrownBy(() -> deckController.cloneDeck(1))
+            .isInstanceOf(RateLimitedException.class);
+        verify(deckService, never()).cloneDeck(any());
+    }
+
+    @Test
+    @DisplayName("Should search decks by name with pagination")
+    void searchDecks_ReturnsMatchingDecksWithPagination() {
+        // Given
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(testDeck1), PageRequest.of(1, 20), 21);
+        when(deckService.searchDecksByName("Yugi", 2, 20)).thenReturn(deckPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.searchDecks("Yugi", 2, 20, false);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody().get("decks")).isEqualTo(List.of(testDeck1));
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getPage()).isEqualTo(2);
+        assertThat(pagination.getTotal()).isEqualTo(21L);
+        assertThat(pagination.getTotalPages()).isEqualTo(2);
+    }
+
+    @Test
+    @DisplayName("Should add page links resolved from firstDeck when asked")
+    void getAllDecks_WithIncludeLinks_AddsLinksToPagination() {
+        // Given: firstDeck 41 lands on page 3 of 5
+        MockHttpServletRequest request = new MockHttpServletRequest("GET", "/decks");
+        request.setQueryString("firstDeck=41&limit=20&include_links=true");
+        RequestContextHolder.setRequestAttributes(new ServletRequestAttributes(request));
+        when(deckService.calculatePageFromDeckId(41, 20, null, false, null, null)).thenReturn(3);
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(testDeck1), PageRequest.of(2, 20), 81);
+        when(deckService.getAllDecks(3, 20, null, null, null, null, null)).thenReturn(deckPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response =
+            deckController.getAllDecks(null, 20, 41, null, null, null, null, null, true);
+
+        // Then
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getLinks())
+            .containsEntry("prev", "http://localhost/decks?limit=20&include_links=true&page=2")
+            .containsEntry("next", "http://localhost/decks?limit=20&include_links=true&page=4")
+            .containsEntry("last", "http://localhost/decks?limit=20&include_links=true&page=5");
+        @SuppressWarnings("unchecked")
+        Map<String, Object> applied = (Map<String, Object>) response.getBody().get("applied");
+        assertThat(applied).containsEntry("include_links", true);
+    }
+
+    @Test
+    @DisplayName("Should return an empty array when no decks match")
+    void searchDecks_WithNoMatchesAndInvalidPage_ReturnsEmptyFirstPage() {
+        // Given
+        when(deckService.searchDecksByName("zzz", 1, 20)).thenReturn(Page.empty(PageRequest.of(0, 20)));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.searchDecks("zzz", 0, 20, false);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat((List<?>) response.getBody().get("decks")).isEmpty();
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getPage()).isEqualTo(1);
+        assertThat(pagination.getTotal()).isZero();
+    }
+
+    @Test
+    @DisplayName("Should pass the sort key through to the service")
+    void getAllDecks_WithSort_PassesSortToService() {
+        // Given
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(testDeck1), PageRequest.of(0, 20), 1);
+        when(deckService.getAllDecks(1, 20, null, null, "-cost", null, null)).thenReturn(deckPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, 20, null, null, null, "-cost", null, null, false);
+
+        // Then
+        assertThat(response.getBody().get("decks")).isEqualTo(List.of(testDeck1));
+    }
+
+    @Test
+    @DisplayName("Should echo the applied parameters, leaving out ignored ones")
+    void getAllDecks_EchoesAppliedParameters() {
+        // Given
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(testDeck1), PageRequest.of(1, 10), 11);
+        when(deckService.getAllDecks(2, 10, "Dragon", null, "-cost", null, null)).thenReturn(deckPage);
+
+        // When: preset=false is not a filter, so it is reported as ignored
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(2, 10, null, "Dragon", false, " -cost ", null, null, false);
+
+        // Then
+        assertThat(response.getBody().get("applied")).isEqualTo(Map.of(
+            "page", 2, "limit", 10, "archetype", "Dragon", "sort", "-cost", "ignored", List.of("preset")));
+    }
+
+    @Test
+    @DisplayName("Should pass and echo the total cost range")
+    void getAllDecks_WithCostRange_PassesAndEchoesIt() {
+        // Given
+        Page<DeckSummary> deckPage = new PageImpl<>(List.of(testDeck1), PageRequest.of(0, 20), 1);
+        when(deckService.getAllDecks(1, 20, null, null, null, 90, 100)).thenReturn(deckPage);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.getAllDecks(null, 20, null, null, null, null, 90, 100, false);
+
+        // Then
+        assertThat(response.getBody().get("applied")).isEqualTo(Map.of(
+            "page", 1, "limit", 20, "min_cost", 90, "max_cost", 100));
+        PaginationResponse pagination = (PaginationResponse) response.getBody().get("pagination");
+        assertThat(pagination.getTotal()).isEqualTo(1L);
+    }
+
+    @Test
+    @DisplayName("Should echo the trimmed query in search results")
+    void searchDecks_EchoesAppliedParameters() {
+        // Given
+        when(deckService.searchDecksByName(" yugi ", 1, 20)).thenReturn(Page.empty(PageRequest.of(0, 20)));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.searchDecks(" yugi ", -1, 20, false);
+
+        // Then
+        assertThat(response.getBody().get("applied")).isEqualTo(Map.of(
+            "q", "yugi", "page", 1, "limit", 20, "ignored", List.of("page")));
+    }
+
+    @Test
+    @DisplayName("Should return just the deck count, treating preset=false as no filter")
+    void countDecks_ReturnsTotal() {
+        // Given
+        when(deckService.countDecks("Dragon", null, null, null)).thenReturn(7L);
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.countDecks("Dragon", false, null, null);
+
+        // Then
+        assertThat(response.getBody()).isEqualTo(Map.of("total", 7L));
+    }
+
+    @Test
+    @DisplayName("Should reject a limit outside 1..100")
+    void getAllDecks_WithLimitOutOfRange_Throws() {
+        assertThatThrownBy(() -> deckController.getAllDecks(1, -5, null, null, null, null, null, null, false))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'limit' must be between 1 and 100, got -5");
+        assertThatThrownBy(() -> deckController.searchDecks("yugi", 1, 500, false))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'limit' must be between 1 and 100, got 500");
+    }
+
+    @Test
+    @DisplayName("Should get a deck by name")
+    void getDeckByName_WhenFound_ReturnsDeck() {
+        // Given
+        DeckWithCards deck = new DeckWithCards();
+        deck.setId(1);
+        deck.setName("Yugi's Deck");
+        when(deckService.getDeckByName("Yugi's Deck")).thenReturn(Optional.of(deck));
+
+        // When
+        ResponseEntity<DeckWithCards> response = deckController.getDeckByName("Yugi's Deck");
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody()).isSameAs(deck);
+    }
+
+    @Test
+    @DisplayName("Should throw not found when no deck has the name")
+    void getDeckByName_WhenMissing_ThrowsNotFound() {
+        when(deckService.getDeckByName("Nobody")).thenReturn(Optional.empty());
+
+        assertThatThrownBy(() -> deckController.getDeckByName("Nobody"))
+            .isInstanceOf(ResourceNotFoundException.class)
+            .hasMessage("Deck Nobody not found");
+    }
+
+    @Test
+    @DisplayName("Should return batch-loaded decks and report missing IDs")
+    void getDecksByIds_WithMissingId_ReportsItSeparately() {
+        // Given
+        DeckWithCards deck1 = new DeckWithCards();
+        deck1.setId(1);
+        DeckWithCards deck3 = new DeckWithCards();
+        deck3.setId(3);
+        when(deckService.getDecksByIds(List.of(3, 2, 1))).thenReturn(List.of(deck3, deck1));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.getDecksByIds(List.of(3, 2, 1, 3));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody().get("decks")).isEqualTo(List.of(deck3, deck1));
+        assertThat(response.getBody().get("missing")).isEqualTo(List.of(2));
+    }
+
+    @Test
+    @DisplayName("Should accept batch deck IDs as a JSON array body")
+    void postDecksByIds_WithJsonArray_ReturnsDecks() {
+        // Given
+        DeckWithCards deck1 = new DeckWithCards();
+        deck1.setId(1);
+        when(deckService.getDecksByIds(List.of(1, 2))).thenReturn(List.of(deck1));
+
+        // When
+        ResponseEntity<Map<String, Object>> response = deckController.postDecksByIds(List.of(1, 2));
+
+        // Then
+        assertThat(response.getBody().get("decks")).isEqualTo(List.of(deck1));
+        assertThat(response.getBody().get("missing")).isEqualTo(List.of(2));
+    }
+
+    @Test
+    @DisplayName("Should reject an empty ids query parameter as an invalid parameter")
+    void getDecksByIds_WithEmptyIds_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> deckController.getDecksByIds(List.of()))
+            .isInstanceOf(InvalidParameterException.class);
+        verify(deckService, never()).getDecksByIds(any());
+    }
+
+    @Test
+    @DisplayName("Should reject an empty or oversized JSON body as a validation failure")
+    void postDecksByIds_WithInvalidBody_ThrowsValidation() {
+        List<Integer> tooMany = IntStream.rangeClosed(1, BatchIds.MAX_IDS + 1).boxed().toList();
+
+        assertThatThrownBy(() -> deckController.postDecksByIds(List.of()))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body must list at least one ID");
+        assertThatThrownBy(() -> deckController.postDecksByIds(tooMany))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Body accepts at most 20 IDs, got 21");
+        verify(deckService, never()).getDecksByIds(any());
+    }
 }
@@ -2,22 +2,22 @@ package com.yugioh.controller;
 
 import org.junit.jupiter.api.DisplayName;
 import org.junit.jupiter.api.Test;
-import org.junit.jupiter.api.extension.ExtendWith;
-import org.mockito.InjectMocks;
-import org.mockito.junit.jupiter.MockitoExtension;
 import org.springframework.http.HttpStatus;
 import org.springframework.http.ResponseEntity;
+import org.springframework.test.util.ReflectionTestUtils;
 
+import java.time.Clock;
+import java.time.Instant;
+import java.time.ZoneOffset;
 import java.util.Map;
 
 import static org.assertj.core.api.Assertions.assertThat;
 
-@ExtendWith(MockitoExtension.class)
 @DisplayName("HealthController Tests")
 class HealthControllerTest {
 
-    @InjectMocks
-    private HealthController healthController;
+    private final HealthController healthController = new HealthController(
+        Clock.fixed(Instant.parse("2026-03-01T12:00:00Z"), ZoneOffset.UTC));
 
     @Test
     @DisplayName("Should return healthy status")
@@ -39,7 +39,36 @@ class HealthControllerTest {
 
         // Then
         assertThat(response.getBody()).isInstanceOf(Map.class);
-        assertThat(response.getBody().size()).isEqualTo(1);
+        assertThat(response.getBody().size()).isEqualTo(2);
         assertThat(response.getBody().containsKey("status")).isTrue();
     }
+
+    @Test
+    @DisplayName("Should stamp the response with the clock's time")
+    void healthCheck_IncludesTimestamp() {
+        // When
+        ResponseEntity<Map<String, String>> response = healthController.healthCheck();
+
+        // Then
+        assertThat(response.getBody()).containsEntry("timestamp", "2026-03-01T12:00:00Z");
+    }
+
+    @Test
+    @DisplayName("Should return build version info")
+    void version_ReturnsBuildInfo() {
+        // Given
+        ReflectionTestUtils.setField(healthController, "buildVersion", "1.2.0");
+        ReflectionTestUtils.setField(healthController, "buildCommit", "abc1234");
+        ReflectionTestUtils.setField(healthController, "buildTime", "2026-01-01T00:00:00Z");
+
+        // When
+        ResponseEntity<Map<String, String>> response = healthController.version();
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.OK);
+        assertThat(response.getBody())
+            .containsEntry("version", "1.2.0")
+            .containsEntry("commit", "abc1234")
+            .containsEntry("buildTime", "2026-01-01T00:00:00Z");
+    }
 }
@@ -0,0 +1,73 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.InvalidParameterException;
+import org.junit.jupiter.api.AfterEach;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.mock.web.MockHttpServletRequest;
+import org.springframework.web.context.request.RequestContextHolder;
+import org.springframework.web.context.request.ServletRequestAttributes;
+
+import java.util.Map;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
+
+@DisplayName("PageParams Tests")
+class PageParamsTest {
+
+    @AfterEach
+    void tearDown() {
+        RequestContextHolder.resetRequestAttributes();
+    }
+
+    private static void currentRequest(String uri, String query) {
+        MockHttpServletRequest request = new MockHttpServletRequest("GET", uri);
+        request.setQueryString(query);
+        RequestContextHolder.setRequestAttributes(new ServletRequestAttributes(request));
+    }
+
+    @Test
+    @DisplayName("Should reject a limit outside 1..MAX_LIMIT")
+    void checkLimit_OutOfRange_ThrowsInvalidParameter() {
+        assertThat(PageParams.checkLimit(PageParams.MAX_LIMIT)).isEqualTo(PageParams.MAX_LIMIT);
+        assertThatThrownBy(() -> PageParams.checkLimit(0))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'limit' must be between 1 and 100, got 0");
+    }
+
+    @Test
+    @DisplayName("Should link every neighbour of a middle page, keeping the other query params")
+    void links_OnMiddlePage_IncludesPrevAndNext() {
+        currentRequest("/decks", "page=2&limit=20&archetype=Dragon&include_links=true");
+
+        Map<String, String> links = PageParams.links(2, 5);
+
+        assertThat(links).containsExactly(
+            Map.entry("first", "http://localhost/decks?page=1&limit=20&archetype=Dragon&include_links=true"),
+            Map.entry("prev", "http://localhost/decks?page=1&limit=20&archetype=Dragon&include_links=true"),
+            Map.entry("next", "http://localhost/decks?page=3&limit=20&archetype=Dragon&include_links=true"),
+            Map.entry("last", "http://localhost/decks?page=5&limit=20&archetype=Dragon&include_links=true"));
+    }
+
+    @Test
+    @DisplayName("Should leave out prev on the first page and next on the last")
+    void links_OnOnlyPage_HasNoPrevOrNext() {
+        currentRequest("/cards", "limit=24");
+
+        Map<String, String> links = PageParams.links(1, 1);
+
+        assertThat(links.keySet()).containsExactly("first", "last");
+        assertThat(links.get("last")).isEqualTo("http://localhost/cards?limit=24&page=1");
+    }
+
+    @Test
+    @DisplayName("Should drop parameters that override the page")
+    void links_WithOverridingParam_DropsIt() {
+        currentRequest("/decks", "firstDeck=41&limit=20");
+
+        Map<String, String> links = PageParams.links(3, 4, "firstDeck");
+
+        assertThat(links.get("next")).isEqualTo("http://localhost/decks?limit=20&page=4");
+    }
+}
@@ -0,0 +1,46 @@
+package com.yugioh.controller;
+
+import com.yugioh.exception.GlobalExceptionHandler;
+import org.junit.jupiter.api.BeforeEach;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.http.MediaType;
+import org.springframework.test.web.servlet.MockMvc;
+import org.springframework.test.web.servlet.setup.MockMvcBuilders;
+
+import java.time.Clock;
+
+import static org.springframework.test.web.servlet.request.MockMvcRequestBuilders.get;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.content;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.header;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.jsonPath;
+import static org.springframework.test.web.servlet.result.MockMvcResultMatchers.status;
+
+/**
+ * Paths that match no controller return the same JSON error body as a
+ * missing resource, with a CORS origin so the frontend can read it.
+ */
+@DisplayName("Unknown Route Tests")
+class UnknownRouteTest {
+
+    private MockMvc mockMvc;
+
+    @BeforeEach
+    void setUp() {
+        mockMvc = MockMvcBuilders
+            .standaloneSetup(new CardController(), new DeckController(), new HealthController(Clock.systemUTC()))
+            .setControllerAdvice(new GlobalExceptionHandler(Clock.systemUTC()))
+            .build();
+    }
+
+    @Test
+    @DisplayName("Should return a JSON not_found error with CORS origin for an unknown path")
+    void get_UnknownPath_ReturnsJsonNotFound() throws Exception {
+        mockMvc.perform(get("/dekcs").header("Origin", "http://localhost:8082"))
+            .andExpect(status().isNotFound())
+            .andExpect(content().contentTypeCompatibleWith(MediaType.APPLICATION_JSON))
+            .andExpect(header().string("Access-Control-Allow-Origin", "*"))
+            .andExpect(jsonPath("$.error.code").value("not_found"))
+            .andExpect(jsonPath("$.error.message").value("No route for GET /dekcs"));
+    }
+}
@@ -0,0 +1,36 @@
+package com.yugioh.dto;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("ApiError Tests")
+class ApiErrorTest {
+
+    @Test
+    @DisplayName("Should create ApiError with no-args constructor")
+    void constructor_NoArgs_CreatesEmptyObject() {
+        // When
+        ApiError error = new ApiError();
+
+        // Then
+        assertThat(error.getCode()).isNull();
+        assertThat(error.getMessage()).isNull();
+    }
+
+    @Test
+    @DisplayName("Should set and get code and message")
+    void setters_AndGetters_WorkCorrectly() {
+        // Given
+        ApiError error = new ApiError();
+
+        // When
+        error.setCode("not_found");
+        error.setMessage("Deck 1 not found");
+
+        // Then
+        assertThat(error.getCode()).isEqualTo("not_found");
+        assertThat(error.getMessage()).isEqualTo("Deck 1 not found");
+    }
+}
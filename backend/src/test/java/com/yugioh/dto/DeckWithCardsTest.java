@@ -1,5 +1,6 @@
 package com.yugioh.dto;
 
+import com.fasterxml.jackson.databind.ObjectMapper;
 import com.yugioh.model.Card;
 import org.junit.jupiter.api.DisplayName;
 import org.junit.jupiter.api.Test;
@@ -26,10 +27,11 @@ class DeckWithCardsTest {
         assertThat(deckWithCards.getCharacterName()).isNull();
         assertThat(deckWithCards.getArchetype()).isNull();
         assertThat(deckWithCards.getMostCommonType()).isNull();
-        assertThat(deckWithCards.getCards()).isNull();
+        assertThat(deckWithCards.getCards()).isEmpty();
         assertThat(deckWithCards.getMaxCost()).isNull();
         assertThat(deckWithCards.getTotalCost()).isNull();
         assertThat(deckWithCards.getIsPreset()).isNull();
+        assertThat(deckWithCards.getComposition()).isNull();
     }
 
     @Test
@@ -47,6 +49,7 @@ class DeckWithCardsTest {
         Integer maxCost = 100;
         Integer totalCost = 95;
         Boolean isPreset = true;
+        DeckComposition composition = new DeckComposition(18, 7, 5, 0.6);
 
         // When
         deckWithCards.setId(id);
@@ -59,6 +62,7 @@ class DeckWithCardsTest {
         deckWithCards.setMaxCost(maxCost);
         deckWithCards.setTotalCost(totalCost);
         deckWithCards.setIsPreset(isPreset);
+        deckWithCards.setComposition(composition);
 
         // Then
         assertThat(deckWithCards.getId()).isEqualTo(id);
@@ -71,11 +75,12 @@ class DeckWithCardsTest {
         assertThat(deckWithCards.getMaxCost()).isEqualTo(maxCost);
         assertThat(deckWithCards.getTotalCost()).isEqualTo(totalCost);
         assertThat(deckWithCards.getIsPreset()).isEqualTo(isPreset);
+        assertThat(deckWithCards.getComposition()).isEqualTo(composition);
     }
 
     @Test
-    @DisplayName("Should handle null cards list")
-    void setCards_WithNull_WorksCorrectly() {
+    @DisplayName("Should store a null cards list as empty")
+    void setCards_WithNull_StoresEmptyList() {
         // Given
         DeckWithCards deckWithCards = new DeckWithCards();
 
@@ -83,7 +88,17 @@ class DeckWithCardsTest {
         deckWithCards.setCards(null);
 
         // Then
-        assertThat(deckWithCards.getCards()).isNull();
+        assertThat(deckWithCards.getCards()).isNotNull().isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should serialize an empty deck's cards as an empty array")
+    void serialize_WithNoCards_WritesEmptyArray() throws Exception {
+        // When
+        String json = new ObjectMapper().writeValueAsString(new DeckWithCards());
+
+        // Then
+        assertThat(json).contains("\"cards\":[]");
     }
 
     @Test
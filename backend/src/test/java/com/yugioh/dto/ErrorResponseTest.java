@@ -0,0 +1,45 @@
+package com.yugioh.dto;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("ErrorResponse Tests")
+class ErrorResponseTest {
+
+    @Test
+    @DisplayName("Should create ErrorResponse with no-args constructor")
+    void constructor_NoArgs_CreatesEmptyObject() {
+        // When
+        ErrorResponse response = new ErrorResponse();
+
+        // Then
+        assertThat(response.getError()).isNull();
+    }
+
+    @Test
+    @DisplayName("Should create ErrorResponse with code and message")
+    void constructor_WithCodeAndMessage_SetsError() {
+        // When
+        ErrorResponse response = new ErrorResponse("not_found", "Card 1 not found");
+
+        // Then
+        assertThat(response.getError().getCode()).isEqualTo("not_found");
+        assertThat(response.getError().getMessage()).isEqualTo("Card 1 not found");
+    }
+
+    @Test
+    @DisplayName("Should set and get error")
+    void setError_AndGetError_WorksCorrectly() {
+        // Given
+        ErrorResponse response = new ErrorResponse();
+        ApiError error = new ApiError("invalid_id", "bad id");
+
+        // When
+        response.setError(error);
+
+        // Then
+        assertThat(response.getError()).isSameAs(error);
+    }
+}
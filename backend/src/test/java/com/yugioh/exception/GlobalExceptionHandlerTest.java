@@ -0,0 +1,281 @@
+package com.yugioh.exception;
+
+import com.fasterxml.jackson.databind.DeserializationFeature;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.exc.MismatchedInputException;
+import com.yugioh.dto.ErrorResponse;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.springframework.core.MethodParameter;
+import org.springframework.http.HttpInputMessage;
+import org.springframework.http.HttpStatus;
+import org.springframework.http.ResponseEntity;
+import org.springframework.http.converter.HttpMessageNotReadableException;
+import org.springframework.web.method.annotation.MethodArgumentTypeMismatchException;
+
+import java.time.Clock;
+import java.time.Instant;
+import java.time.ZoneOffset;
+import java.util.List;
+
+import static org.assertj.core.api.Assertions.assertThat;
+import static org.mockito.Mockito.mock;
+
+@DisplayName("GlobalExceptionHandler Tests")
+class GlobalExceptionHandlerTest {
+
+    private final GlobalExceptionHandler handler = new GlobalExceptionHandler(
+        Clock.fixed(Instant.parse("2026-03-01T12:00:00Z"), ZoneOffset.UTC));
+
+    private final ObjectMapper objectMapper = new ObjectMapper()
+        .configure(DeserializationFeature.FAIL_ON_UNKNOWN_PROPERTIES, true);
+
+    static class Nested {
+        public String value;
+    }
+
+    static class RequestBody {
+        public Integer level;
+        public boolean active;
+        public String name;
+        public List<Integer> ids;
+        public Nested nested;
+    }
+
+    private ErrorResponse readBody(String json) {
+        Throwable cause = null;
+        try {
+            objectMapper.readValue(json, RequestBody.class);
+        } catch (Exception e) {
+            cause = e;
+        }
+        return readError(cause);
+    }
+
+    private ErrorResponse readError(Throwable cause) {
+        return readError("JSON parse error", cause);
+    }
+
+    private ErrorResponse readError(String message, Throwable cause) {
+        HttpMessageNotReadableException ex =
+            new HttpMessageNotReadableException(message, cause, mock(HttpInputMessage.class));
+        ResponseEntity<ErrorResponse> response = handler.handleUnreadableBody(ex);
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.BAD_REQUEST);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("invalid_body");
+        return response.getBody();
+    }
+
+    @Test
+    @DisplayName("Should return invalid_id for a malformed ID path variable")
+    void handleTypeMismatch_WithIdParameter_ReturnsInvalidId() {
+        // Given
+        MethodArgumentTypeMismatchException ex = new MethodArgumentTypeMismatchException(
+            "abc", Integer.class, "id", mock(MethodParameter.class), null);
+
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleTypeMismatch(ex);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.BAD_REQUEST);
+        assertThat(response.getBody()).isNotNull();
+        assertThat(response.getBody().getError().getCode()).isEqualTo("invalid_id");
+        assertThat(response.getBody().getError().getMessage())
+            .isEqualTo("Parameter 'id' must be a valid Integer, got 'abc'");
+    }
+
+    @Test
+    @DisplayName("Should return invalid_parameter for other malformed parameters")
+    void handleTypeMismatch_WithOtherParameter_ReturnsInvalidParameter() {
+        // Given
+        MethodArgumentTypeMismatchException ex = new MethodArgumentTypeMismatchException(
+            "yes", Boolean.class, "preset", mock(MethodParameter.class), null);
+
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleTypeMismatch(ex);
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.BAD_REQUEST);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("invalid_parameter");
+        assertThat(response.getBody().getError().getMessage())
+            .isEqualTo("Parameter 'preset' must be a valid Boolean, got 'yes'");
+    }
+
+    @Test
+    @DisplayName("Should describe value when required type is unknown")
+    void handleTypeMismatch_WithoutRequiredType_UsesGenericType() {
+        // Given
+        MethodArgumentTypeMismatchException ex = new MethodArgumentTypeMismatchException(
+            "abc", null, "id", mock(MethodParameter.class), null);
+
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleTypeMismatch(ex);
+
+        // Then
+        assertThat(response.getBody().getError().getMessage())
+            .isEqualTo("Parameter 'id' must be a valid value, got 'abc'");
+    }
+
+    @Test
+    @DisplayName("Should return invalid_parameter for unsupported parameter values")
+    void handleInvalidParameter_ReturnsBadRequest() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleInvalidParameter(
+            new InvalidParameterException("sort", "Parameter 'sort' must be one of [cost, -cost, power, -power], got 'name'"));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.BAD_REQUEST);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("invalid_parameter");
+        assertThat(response.getBody().getError().getMessage())
+            .isEqualTo("Parameter 'sort' must be one of [cost, -cost, power, -power], got 'name'");
+    }
+
+    @Test
+    @DisplayName("Should return not_found echoing the requested ID")
+    void handleNotFound_ReturnsNotFoundWithId() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleNotFound(new ResourceNotFoundException("Deck", 7));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.NOT_FOUND);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("not_found");
+        assertThat(response.getBody().getError().getMessage()).isEqualTo("Deck 7 not found");
+    }
+
+    @Test
+    @DisplayName("Should stamp error bodies with the clock's time")
+    void handleNotFound_IncludesTimestamp() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleNotFound(new ResourceNotFoundException("Deck", 7));
+
+        // Then
+        assertThat(response.getBody().getTimestamp()).isEqualTo("2026-03-01T12:00:00Z");
+    }
+
+    @Test
+    @DisplayName("Should return conflict for ambiguous lookups")
+    void handleConflict_ReturnsConflict() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleConflict(
+            new ConflictException("Deck name 'yugi' matches 2 decks"));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.CONFLICT);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("conflict");
+        assertThat(response.getBody().getError().getMessage()).isEqualTo("Deck name 'yugi' matches 2 decks");
+    }
+
+    @Test
+    @DisplayName("Should return too many requests when a rate limit is hit")
+    void handleRateLimited_ReturnsTooManyRequests() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleRateLimited(
+            new RateLimitedException("At most 10 decks can be cloned per minute, try again later"));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.TOO_MANY_REQUESTS);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("rate_limited");
+        assertThat(response.getBody().getError().getMessage())
+            .isEqualTo("At most 10 decks can be cloned per minute, try again later");
+    }
+
+    @Test
+    @DisplayName("Should return forbidden for read-only resources")
+    void handleForbidden_ReturnsForbidden() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleForbidden(
+            new ForbiddenException("Deck 1 is a preset deck and cannot be modified"));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.FORBIDDEN);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("forbidden");
+        assertThat(response.getBody().getError().getMessage()).isEqualTo("Deck 1 is a preset deck and cannot be modified");
+    }
+
+    @Test
+    @DisplayName("Should return 422 for well-formed bodies that fail validation")
+    void handleValidation_ReturnsUnprocessableEntity() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleValidation(
+            new ValidationException("Deck total cost 9 exceeds new max cost 8"));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.UNPROCESSABLE_ENTITY);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("validation_failed");
+        assertThat(response.getBody().getError().getMessage()).isEqualTo("Deck total cost 9 exceeds new max cost 8");
+    }
+
+    @Test
+    @DisplayName("Should return service unavailable when the database is down")
+    void handleDatabaseUnavailable_ReturnsServiceUnavailable() {
+        // When
+        ResponseEntity<ErrorResponse> response = handler.handleDatabaseUnavailable(
+            new DatabaseUnavailableException("Database is temporarily unavailable", new RuntimeException()));
+
+        // Then
+        assertThat(response.getStatusCode()).isEqualTo(HttpStatus.SERVICE_UNAVAILABLE);
+        assertThat(response.getBody().getError().getCode()).isEqualTo("service_unavailable");
+        assertThat(response.getBody().getError().getMessage()).isEqualTo("Database is temporarily unavailable");
+    }
+
+    @Test
+    @DisplayName("Should reject unknown fields by name")
+    void handleUnreadableBody_WithUnknownField_NamesField() {
+        assertThat(readBody("{\"levle\": 5}").getError().getMessage()).isEqualTo("Unknown field 'levle'");
+    }
+
+    @Test
+    @DisplayName("Should name the field and expected type on a type mismatch")
+    void handleUnreadableBody_WithTypeMismatch_NamesFieldAndType() {
+        assertThat(readBody("{\"level\": \"high\"}").getError().getMessage())
+            .isEqualTo("Field 'level' must be a number");
+        assertThat(readBody("{\"active\": \"maybe\"}").getError().getMessage())
+            .isEqualTo("Field 'active' must be a boolean");
+        assertThat(readBody("{\"name\": [1]}").getError().getMessage())
+            .isEqualTo("Field 'name' must be a string");
+        assertThat(readBody("{\"ids\": 5}").getError().getMessage())
+            .isEqualTo("Field 'ids' must be an array");
+        assertThat(readBody("{\"ids\": [\"a\"]}").getError().getMessage())
+            .isEqualTo("Field 'ids[0]' must be a number");
+        assertThat(readBody("{\"nested\": 5}").getError().getMessage())
+            .isEqualTo("Field 'nested' must be a valid Nested");
+    }
+
+    @Test
+    @DisplayName("Should describe a body of the wrong shape")
+    void handleUnreadableBody_WithWrongRootType_DescribesBody() {
+        assertThat(readBody("[1]").getError().getMessage()).isEqualTo("Request body must be a valid RequestBody");
+        assertThat(readError(MismatchedInputException.from(null, (Class<?>) null, "bad")).getError().getMessage())
+            .isEqualTo("Request body must be a valid value");
+    }
+
+    @Test
+    @DisplayName("Should report malformed JSON")
+    void handleUnreadableBody_WithMalformedJson_ReportsSyntaxError() {
+        assertThat(readBody("{\"level\": ").getError().getMessage()).startsWith("Malformed JSON: ");
+    }
+
+    @Test
+    @DisplayName("Should fall back to a generic message without a Jackson cause")
+    void handleUnreadableBody_WithoutCause_ReturnsGenericMessage() {
+        assertThat(readError(null).getError().getMessage()).isEqualTo("Invalid request body");
+    }
+
+    @Test
+    @DisplayName("Should require a body when the request body is empty")
+    void handleUnreadableBody_WithEmptyBody_ReportsBodyRequired() {
+        assertThat(readError("Required request body is missing: public ResponseEntity DeckController.updateDeck()", null)
+            .getError().getMessage()).isEqualTo("Request body is required");
+    }
+
+    @Test
+    @DisplayName("Should require a body when the request body is only whitespace")
+    void handleUnreadableBody_WithWhitespaceBody_ReportsBodyRequired() {
+        assertThat(readBody("   ").getError().getMessage()).isEqualTo("Request body is required");
+    }
+
+    @Test
+    @DisplayName("Should fall back to a generic message without an exception message")
+    void handleUnreadableBody_WithoutMessageOrCause_ReturnsGenericMessage() {
+        assertThat(readError(null, null).getError().getMessage()).isEqualTo("Invalid request body");
+    }
+}
@@ -0,0 +1,22 @@
+package com.yugioh.exception;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("ResourceNotFoundException Tests")
+class ResourceNotFoundExceptionTest {
+
+    @Test
+    @DisplayName("Should echo resource and ID in message")
+    void constructor_SetsResourceIdAndMessage() {
+        // When
+        ResourceNotFoundException ex = new ResourceNotFoundException("Card", 42);
+
+        // Then
+        assertThat(ex.getResource()).isEqualTo("Card");
+        assertThat(ex.getId()).isEqualTo(42);
+        assertThat(ex.getMessage()).isEqualTo("Card 42 not found");
+    }
+}
@@ -129,4 +129,23 @@ class CardTest {
         card.setUpdatedAt(updatedAt);
         assertThat(card.getUpdatedAt()).isEqualTo(updatedAt);
     }
+
+    @Test
+    @DisplayName("Should copy every field into a separate card")
+    void copy_ReturnsEqualValuesInNewInstance() {
+        card.setId(1);
+        card.setName("Blue-Eyes White Dragon");
+        card.setImage("1.jpg");
+        card.setType("Normal Monster");
+        card.setAttackPoints(3000);
+        card.setCost(5);
+        card.setUpdatedAt(LocalDateTime.of(2024, 1, 1, 12, 0));
+
+        Card copy = card.copy();
+        copy.setImage("https://example.com/1.jpg");
+
+        assertThat(copy).isNotSameAs(card);
+        assertThat(copy).usingRecursiveComparison().ignoringFields("image").isEqualTo(card);
+        assertThat(card.getImage()).isEqualTo("1.jpg");
+    }
 }
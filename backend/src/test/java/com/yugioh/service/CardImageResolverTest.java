@@ -0,0 +1,73 @@
+package com.yugioh.service;
+
+import com.yugioh.model.Card;
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("CardImageResolver Tests")
+class CardImageResolverTest {
+
+    private static final String PLACEHOLDER = "https://example.com/card-back.png";
+
+    private final CardImageResolver resolver = new CardImageResolver("https://cdn.example.com/cards/", PLACEHOLDER);
+
+    @Test
+    @DisplayName("Should keep a well-formed absolute URL")
+    void resolve_WithAbsoluteUrl_ReturnsUrl() {
+        assertThat(resolver.resolve("https://images.ygoprodeck.com/images/cards/1.jpg"))
+            .isEqualTo("https://images.ygoprodeck.com/images/cards/1.jpg");
+    }
+
+    @Test
+    @DisplayName("Should rewrite a relative path against the base URL")
+    void resolve_WithRelativePath_UsesBaseUrl() {
+        assertThat(resolver.resolve("/images/1.jpg")).isEqualTo("https://cdn.example.com/cards/images/1.jpg");
+        assertThat(resolver.resolve("images/2.jpg")).isEqualTo("https://cdn.example.com/cards/images/2.jpg");
+    }
+
+    @Test
+    @DisplayName("Should return placeholder for a relative path without a base URL")
+    void resolve_WithRelativePathAndNoBase_ReturnsPlaceholder() {
+        CardImageResolver noBase = new CardImageResolver(null, PLACEHOLDER);
+
+        assertThat(noBase.resolve("images/1.jpg")).isEqualTo(PLACEHOLDER);
+    }
+
+    @Test
+    @DisplayName("Should return placeholder for empty images")
+    void resolve_WithEmptyImage_ReturnsPlaceholder() {
+        assertThat(resolver.resolve(null)).isEqualTo(PLACEHOLDER);
+        assertThat(resolver.resolve("   ")).isEqualTo(PLACEHOLDER);
+    }
+
+    @Test
+    @DisplayName("Should return placeholder for malformed or non-http URLs")
+    void resolve_WithMalformedUrl_ReturnsPlaceholder() {
+        assertThat(resolver.resolve("https://bad host/1.jpg")).isEqualTo(PLACEHOLDER);
+        assertThat(resolver.resolve("ftp://example.com/1.jpg")).isEqualTo(PLACEHOLDER);
+        assertThat(resolver.resolve("javascript:alert(1)")).isEqualTo(PLACEHOLDER);
+        assertThat(resolver.resolve("http:///1.jpg")).isEqualTo(PLACEHOLDER);
+    }
+
+    @Test
+    @DisplayName("Should return a resolved copy and leave the card unchanged")
+    void apply_ReturnsResolvedCopy() {
+        // Given
+        Card card = new Card();
+        card.setId(1);
+        card.setName("Dark Magician");
+        card.setImage("");
+
+        // When
+        Card result = resolver.apply(card);
+
+        // Then
+        assertThat(result).isNotSameAs(card);
+        assertThat(result.getId()).isEqualTo(1);
+        assertThat(result.getName()).isEqualTo("Dark Magician");
+        assertThat(result.getImage()).isEqualTo(PLACEHOLDER);
+        assertThat(card.getImage()).isEmpty();
+    }
+}
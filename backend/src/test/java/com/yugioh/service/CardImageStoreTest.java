@@ -0,0 +1,62 @@
+package com.yugioh.service;
+
+import org.junit.jupiter.api.DisplayName;
+import org.junit.jupiter.api.Test;
+import org.junit.jupiter.api.io.TempDir;
+
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+
+import static org.assertj.core.api.Assertions.assertThat;
+
+@DisplayName("CardImageStore Tests")
+class CardImageStoreTest {
+
+    @TempDir
+    Path imageDir;
+
+    @Test
+    @DisplayName("Should find an image by its file name")
+    void find_WithExistingFile_ReturnsPath() throws IOException {
+        // Given
+        Path image = Files.writeString(imageDir.resolve("1.jpg"), "jpg");
+        CardImageStore store = new CardImageStore(imageDir.toString());
+
+        // When / Then
+        assertThat(store.find("1.jpg")).contains(image.toAbsolutePath().normalize());
+        assertThat(store.find("/images/cards/1.jpg")).contains(image.toAbsolutePath().normalize());
+        assertThat(store.find("https://cdn.example.com/cards/1.jpg?v=2")).contains(image.toAbsolutePath().normalize());
+    }
+
+    @Test
+    @DisplayName("Should return empty when the file does not exist")
+    void find_WithMissingFile_ReturnsEmpty() {
+        CardImageStore store = new CardImageStore(imageDir.toString());
+
+        assertThat(store.find("missing.jpg")).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should return empty when no image directory is configured")
+    void find_WithoutDirectory_ReturnsEmpty() {
+        assertThat(new CardImageStore("").find("1.jpg")).isEmpty();
+        assertThat(new CardImageStore(null).find("1.jpg")).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should return empty for unusable image references")
+    void find_WithInvalidReference_ReturnsEmpty() throws IOException {
+        Files.createDirectory(imageDir.resolve("nested"));
+        CardImageStore store = new CardImageStore(imageDir.toString());
+
+        assertThat(store.find(null)).isEmpty();
+        assertThat(store.find(" ")).isEmpty();
+        assertThat(store.find("not a uri")).isEmpty();
+        assertThat(store.find("mailto:someone@example.com")).isEmpty();
+        assertThat(store.find("images/")).isEmpty();
+        assertThat(store.find("..")).isEmpty();
+        assertThat(store.find(".")).isEmpty();
+        assertThat(store.find("nested")).isEmpty();
+    }
+}
@@ -1,6 +1,10 @@
 package com.yugioh.service;
 
+import com.yugioh.config.DatabaseReadRetry;
+import com.yugioh.dto.PopularCard;
+import com.yugioh.exception.InvalidParameterException;
 import com.yugioh.model.Card;
+import com.yugioh.model.DeckCard;
 import com.yugioh.repository.CardRepository;
 import org.junit.jupiter.api.BeforeEach;
 import org.junit.jupiter.api.DisplayName;
@@ -9,6 +13,7 @@ import org.junit.jupiter.api.extension.ExtendWith;
 import org.mockito.ArgumentCaptor;
 import org.mockito.InjectMocks;
 import org.mockito.Mock;
+import org.mockito.Spy;
 import org.mockito.junit.jupiter.MockitoExtension;
 import org.springframework.data.domain.Page;
 import org.springframework.data.domain.PageImpl;
@@ -16,16 +21,25 @@ import org.springframework.data.domain.PageRequest;
 import org.springframework.data.domain.Sort;
 import org.springframework.data.jpa.domain.Specification;
 
+import jakarta.persistence.EntityManager;
+import jakarta.persistence.Tuple;
+import jakarta.persistence.TypedQuery;
 import jakarta.persistence.criteria.CriteriaBuilder;
 import jakarta.persistence.criteria.CriteriaQuery;
 import jakarta.persistence.criteria.Path;
 import jakarta.persistence.criteria.Predicate;
 import jakarta.persistence.criteria.Root;
+import jakarta.persistence.criteria.Selection;
+import jakarta.persistence.criteria.Subquery;
+import java.util.ArrayList;
 import java.util.Arrays;
 import java.util.List;
+import java.util.Map;
 import java.util.Optional;
+import java.util.concurrent.atomic.AtomicBoolean;
 
 import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
 import static org.mockito.ArgumentMatchers.*;
 import static org.mockito.Mockito.*;
 
@@ -37,6 +51,16 @@ class CardServiceTest {
     @Mock
     private CardRepository cardRepository;
 
+    @Mock
+    private EntityManager entityManager;
+
+    @Spy
+    private CardImageResolver cardImageResolver =
+        new CardImageResolver("", "https://example.com/placeholder.png");
+
+    @Spy
+    private DatabaseReadRetry databaseReadRetry = new DatabaseReadRetry(0);
+
     @InjectMocks
     private CardService cardService;
 
@@ -80,13 +104,13 @@ class CardServiceTest {
         when(cardRepository.findAll(any(PageRequest.class))).thenReturn(cardPage);
 
         // When
-        Page<Card> result = cardService.getAllCards(page, limit, null);
+        Page<Card> result = cardService.getAllCards(page, limit, null, null);
 
         // Then
         assertThat(result).isNotNull();
         assertThat(result.getContent()).hasSize(3);
         assertThat(result.getTotalElements()).isEqualTo(100L);
-        assertThat(result.getContent()).containsExactlyElementsOf(testCards);
+        assertThat(result.getContent()).extracting(Card::getId).containsExactly(1, 2, 3);
         verify(cardRepository).findAll(pageRequest);
     }
 
@@ -105,13 +129,13 @@ class CardServiceTest {
         when(cardRepository.findAll(specCaptor.capture(), any(PageRequest.class))).thenReturn(cardPage);
 
         // When
-        Page<Card> result = cardService.getAllCards(page, limit, startId);
+        Page<Card> result = cardService.getAllCards(page, limit, startId, null);
 
         // Then
         assertThat(result).isNotNull();
         assertThat(result.getContent()).hasSize(2);
         assertThat(result.getTotalElements()).isEqualTo(50L);
-        assertThat(result.getContent()).containsExactlyElementsOf(filteredCards);
+        assertThat(result.getContent()).extracting(Card::getId).containsExactly(2, 3);
 
         // Execute the captured Specification to ensure lambda code is covered
         Specification<Card> capturedSpec = specCaptor.getValue();
@@ -134,6 +158,165 @@ class CardServiceTest {
         verify(cb).greaterThanOrEqualTo(any(), eq(startId));
     }
 
+    @Test
+    @DisplayName("Should select only the requested fields in SQL and resolve the image")
+    void getAllCardFields_SelectsRequestedColumns() {
+        // Given
+        CriteriaBuilder cb = mock(CriteriaBuilder.class);
+        CriteriaQuery<Tuple> query = mock(CriteriaQuery.class);
+        Root<Card> root = mock(Root.class);
+        Path<Object> namePath = mock(Path.class);
+        Path<Object> imagePath = mock(Path.class);
+        Path<Object> idPath = mock(Path.class);
+        Selection<Object> nameSelection = mock(Selection.class);
+        Selection<Object> imageSelection = mock(Selection.class);
+        TypedQuery<Tuple> typedQuery = mock(TypedQuery.class);
+        Tuple row = mock(Tuple.class);
+
+        when(entityManager.getCriteriaBuilder()).thenReturn(cb);
+        when(cb.createTupleQuery()).thenReturn(query);
+        when(query.from(Card.class)).thenReturn(root);
+        when(root.get("name")).thenReturn(namePath);
+        when(root.get("image")).thenReturn(imagePath);
+        when(root.get("id")).thenReturn(idPath);
+        when(namePath.alias("name")).thenReturn(nameSelection);
+        when(imagePath.alias("image")).thenReturn(imageSelection);
+        when(entityManager.createQuery(query)).thenReturn(typedQuery);
+        when(typedQuery.setFirstResult(24)).thenReturn(typedQuery);
+        when(typedQuery.setMaxResults(24)).thenReturn(typedQuery);
+        when(typedQuery.getResultList()).thenReturn(List.of(row));
+        when(row.get("name")).thenReturn("Dark Magician");
+        when(row.get("image")).thenReturn(null);
+        when(cardRepository.countWithFilters(null, null)).thenReturn(30L);
+
+        // When
+        Page<Map<String, Object>> result = cardService.getAllCardFields(2, 24, null, null, List.of("name", "image"));
+
+        // Then
+        verify(query).multiselect(List.<Selection<?>>of(nameSelection, imageSelection));
+        verify(query, never()).where(any(Predicate.class));
+        assertThat(result.getContent()).hasSize(1);
+        assertThat(result.getContent().get(0)).containsExactly(
+            Map.entry("name", "Dark Magician"), Map.entry("image", "https://example.com/placeholder.png"));
+        assertThat(result.getTotalElements()).isEqualTo(30L);
+        verify(cardRepository, never()).findAll(any(PageRequest.class));
+    }
+
+    @Test
+    @DisplayName("Should exclude cards already in a deck")
+    void getAllCards_WithExcludeDeck_FiltersOutDeckCards() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 24, Sort.by("id").ascending());
+        Page<Card> cardPage = new PageImpl<>(List.of(testCard3), pageRequest, 1);
+
+        ArgumentCaptor<Specification<Card>> specCaptor = ArgumentCaptor.forClass(Specification.class);
+        when(cardRepository.findAll(specCaptor.capture(), eq(pageRequest))).thenReturn(cardPage);
+
+        // When
+        Page<Card> result = cardService.getAllCards(1, 24, 2, 5);
+
+        // Then
+        assertThat(result.getContent()).extracting(Card::getId).containsExactly(3);
+
+        Root<Card> root = mock(Root.class);
+        CriteriaQuery<?> query = mock(CriteriaQuery.class);
+        CriteriaBuilder cb = mock(CriteriaBuilder.class);
+        Subquery<Integer> subquery = mock(Subquery.class);
+        Root<DeckCard> deckCard = mock(Root.class);
+        Path<Object> idPath = mock(Path.class);
+        Path<Object> cardIdPath = mock(Path.class);
+        Path<Object> deckIdPath = mock(Path.class);
+        Predicate inDeck = mock(Predicate.class);
+        Predicate deckMatches = mock(Predicate.class);
+        Predicate notInDeck = mock(Predicate.class);
+
+        when(root.get("id")).thenReturn(idPath);
+        when(query.subquery(Integer.class)).thenReturn(subquery);
+        when(subquery.from(DeckCard.class)).thenReturn(deckCard);
+        when(deckCard.get("cardId")).thenReturn(cardIdPath);
+        when(deckCard.get("deckId")).thenReturn(deckIdPath);
+        when(subquery.select(any())).thenReturn(subquery);
+        when(cb.equal(deckIdPath, 5)).thenReturn(deckMatches);
+        when(idPath.in(subquery)).thenReturn(inDeck);
+        when(cb.not(inDeck)).thenReturn(notInDeck);
+
+        specCaptor.getValue().toPredicate(root, query, cb);
+
+        verify(subquery).where(deckMatches);
+        verify(cb).greaterThanOrEqualTo(any(), eq(2));
+        verify(cb).not(inDeck);
+    }
+
+    @Test
+    @DisplayName("Should use a specification when only excluding a deck")
+    void getAllCards_WithOnlyExcludeDeck_UsesSpecification() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 24, Sort.by("id").ascending());
+        when(cardRepository.findAll(any(Specification.class), eq(pageRequest)))
+            .thenReturn(new PageImpl<>(testCards, pageRequest, 3));
+
+        // When
+        Page<Card> result = cardService.getAllCards(1, 24, null, 999);
+
+        // Then
+        assertThat(result.getContent()).hasSize(3);
+        verify(cardRepository, never()).findAll(pageRequest);
+    }
+
+    @Test
+    @DisplayName("Should count facets under the listing filters")
+    void getCardFacets_ReturnsCountsAndGrandTotal() {
+        // Given
+        when(cardRepository.count()).thenReturn(10L);
+        when(cardRepository.countByType(2, 5)).thenReturn(List.<Object[]>of(
+            new Object[] {"Monster", 6L}, new Object[] {"Spell", 2L}));
+        when(cardRepository.countByAttribute(2, 5)).thenReturn(List.<Object[]>of(new Object[] {"DARK", 4L}));
+        when(cardRepository.countByRarity(2, 5)).thenReturn(List.of());
+
+        // When
+        Map<String, Object> facets = cardService.getCardFacets(2, 5);
+
+        // Then
+        assertThat(facets).containsEntry("total", 10L);
+        assertThat(facets.get("type")).isEqualTo(Map.of("Monster", 6L, "Spell", 2L));
+        assertThat(facets.get("attribute")).isEqualTo(Map.of("DARK", 4L));
+        assertThat(facets.get("rarity")).isEqualTo(Map.of());
+    }
+
+    @Test
+    @DisplayName("Should ignore a non-positive start ID when counting facets")
+    void getCardFacets_WithInvalidStartId_CountsFromStart() {
+        // Given
+        when(cardRepository.countByType(null, null)).thenReturn(List.of());
+        when(cardRepository.countByAttribute(null, null)).thenReturn(List.of());
+        when(cardRepository.countByRarity(null, null)).thenReturn(List.of());
+
+        // When
+        cardService.getCardFacets(0, null);
+
+        // Then
+        verify(cardRepository).countByType(null, null);
+    }
+
+    @Test
+    @DisplayName("Should pair popular cards with their deck counts")
+    void getPopularCards_MapsRowsToCardsWithDeckCounts() {
+        // Given
+        Page<Object[]> rows = new PageImpl<>(List.<Object[]>of(
+            new Object[] {testCard2, 7L}, new Object[] {testCard1, 3L}), PageRequest.of(0, 2), 5);
+        when(cardRepository.findPopular(false, PageRequest.of(0, 2))).thenReturn(rows);
+
+        // When
+        Page<PopularCard> result = cardService.getPopularCards(1, 2, false);
+
+        // Then
+        assertThat(result.getContent()).extracting(popular -> popular.getCard().getId()).containsExactly(2, 1);
+        assertThat(result.getContent()).extracting(PopularCard::getDeckCount).containsExactly(7L, 3L);
+        assertThat(result.getTotalElements()).isEqualTo(5L);
+        assertThat(result.getContent().get(0).getCard().getImage()).isEqualTo("https://example.com/placeholder.png");
+        assertThat(testCard2.getImage()).isNull();
+    }
+
     @Test
     @DisplayName("Should get card by ID when card exists")
     void getCardById_WhenCardExists_ReturnsCard() {
@@ -151,6 +334,20 @@ class CardServiceTest {
         verify(cardRepository).findById(cardId);
     }
 
+    @Test
+    @DisplayName("Should return the stored image without resolving it")
+    void getStoredImage_ReturnsUnresolvedImage() {
+        // Given
+        testCard1.setImage("1.jpg");
+        when(cardRepository.findById(1)).thenReturn(Optional.of(testCard1));
+        when(cardRepository.findById(2)).thenReturn(Optional.of(testCard2));
+
+        // When / Then
+        assertThat(cardService.getStoredImage(1)).contains("1.jpg");
+        assertThat(cardService.getStoredImage(2)).contains("");
+        assertThat(cardService.getCardById(1).get().getImage()).isEqualTo("https://example.com/placeholder.png");
+    }
+
     @Test
     @DisplayName("Should return empty when card does not exist")
     void getCardById_WhenCardNotExists_ReturnsEmpty() {
@@ -166,6 +363,110 @@ class CardServiceTest {
         verify(cardRepository).findById(cardId);
     }
 
+    @Test
+    @DisplayName("Should look up similar cards by the card's type, attribute and stats")
+    void getSimilarCards_QueriesByCardStats() {
+        // Given
+        testCard1.setAttribute("LIGHT");
+        testCard1.setAttackPoints(3000);
+        testCard1.setDefensePoints(2500);
+        when(cardRepository.findSimilar(1, "Monster", "LIGHT", 5, 3000, 2500, PageRequest.of(0, 2)))
+            .thenReturn(List.of(testCard3, testCard2));
+
+        // When
+        List<Card> result = cardService.getSimilarCards(testCard1, 2);
+
+        // Then
+        assertThat(result).extracting(Card::getId).containsExactly(3, 2);
+    }
+
+    @Test
+    @DisplayName("Should match spells by type and cost with no attribute")
+    void getSimilarCards_WithSpell_MatchesWithoutAttribute() {
+        // Given
+        Card spell = new Card();
+        spell.setId(10);
+        spell.setType("Spell");
+        spell.setCost(2);
+        when(cardRepository.findSimilar(10, "Spell", null, 2, 0, 0, PageRequest.of(0, 10)))
+            .thenReturn(List.of());
+
+        // When
+        List<Card> result = cardService.getSimilarCards(spell, 10);
+
+        // Then
+        assertThat(result).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should reject a similar-card limit out of range")
+    void getSimilarCards_WithLimitOutOfRange_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> cardService.getSimilarCards(testCard1, 0))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'limit' must be between 1 and 50, got 0");
+        verify(cardRepository, never()).findSimilar(any(), any(), any(), anyInt(), anyInt(), anyInt(), any());
+    }
+
+    @Test
+    @DisplayName("Should count cards with the listing filters, ignoring a non-positive start ID")
+    void countCards_UsesListingFilters() {
+        // Given
+        when(cardRepository.countWithFilters(null, 5)).thenReturn(40L);
+
+        // When / Then
+        assertThat(cardService.countCards(0, 5)).isEqualTo(40L);
+    }
+
+    @Test
+    @DisplayName("Should search card names only by default")
+    void searchCards_WithNameField_SearchesNamesOnly() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(1, 10);
+        when(cardRepository.search("magician", true, false, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testCard1), pageRequest, 11));
+
+        // When
+        Page<Card> result = cardService.searchCards(" magician ", List.of("name"), 2, 10);
+
+        // Then
+        assertThat(result.getContent()).extracting(Card::getId).containsExactly(1);
+        assertThat(result.getTotalElements()).isEqualTo(11L);
+    }
+
+    @Test
+    @DisplayName("Should also search descriptions when requested")
+    void searchCards_WithDescriptionField_SearchesDescriptions() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 24);
+        when(cardRepository.search("destroy", true, true, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testCard3), pageRequest, 1));
+
+        // When
+        Page<Card> result = cardService.searchCards("destroy", List.of("name", "description"), 1, 24);
+
+        // Then
+        assertThat(result.getContent()).extracting(Card::getId).containsExactly(3);
+    }
+
+    @Test
+    @DisplayName("Should reject search queries shorter than two characters")
+    void searchCards_WithShortQuery_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> cardService.searchCards(" a ", List.of("name"), 1, 24))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'q' must be at least 2 characters");
+        assertThatThrownBy(() -> cardService.searchCards(null, List.of("name"), 1, 24))
+            .isInstanceOf(InvalidParameterException.class);
+        verify(cardRepository, never()).search(anyString(), anyBoolean(), anyBoolean(), any());
+    }
+
+    @Test
+    @DisplayName("Should reject unknown search fields")
+    void searchCards_WithUnknownField_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> cardService.searchCards("dragon", List.of("name", "race"), 1, 24))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'fields' must only contain [name, description], got 'race'");
+    }
+
     @Test
     @DisplayName("Should get cards by IDs")
     void getCardsByIds_ReturnsMatchingCards() {
@@ -181,7 +482,7 @@ class CardServiceTest {
         // Then
         assertThat(result).isNotNull();
         assertThat(result).hasSize(2);
-        assertThat(result).containsExactlyElementsOf(expectedCards);
+        assertThat(result).extracting(Card::getId).containsExactly(1, 2);
         verify(cardRepository).findByIds(cardIds);
     }
 
@@ -213,7 +514,7 @@ class CardServiceTest {
         when(cardRepository.findAll(any(PageRequest.class))).thenReturn(cardPage);
 
         // When
-        Page<Card> result = cardService.getAllCards(page, limit, null);
+        Page<Card> result = cardService.getAllCards(page, limit, null, null);
 
         // Then
         assertThat(result).isNotNull();
@@ -235,7 +536,7 @@ class CardServiceTest {
         when(cardRepository.findAll(any(PageRequest.class))).thenReturn(cardPage);
 
         // When
-        Page<Card> result = cardService.getAllCards(page, limit, invalidStartId);
+        Page<Card> result = cardService.getAllCards(page, limit, invalidStartId, null);
 
         // Then
         assertThat(result).isNotNull();
@@ -257,7 +558,7 @@ class CardServiceTest {
         when(cardRepository.findAll(any(PageRequest.class))).thenReturn(cardPage);
 
         // When
-        Page<Card> result = cardService.getAllCards(page, limit, negativeStartId);
+        Page<Card> result = cardService.getAllCards(page, limit, negativeStartId, null);
 
         // Then
         assertThat(result).isNotNull();
@@ -277,11 +578,84 @@ class CardServiceTest {
         when(cardRepository.findAll(any(PageRequest.class))).thenReturn(cardPage);
 
         // When
-        Page<Card> result = cardService.getAllCards(page, limit, null);
+        Page<Card> result = cardService.getAllCards(page, limit, null, null);
 
         // Then
         assertThat(result).isNotNull();
         assertThat(result.getContent()).hasSize(3);
         verify(cardRepository).findAll(pageRequest);
     }
+
+    @Test
+    @DisplayName("Should replace an empty card image with the placeholder")
+    void getCardById_WithEmptyImage_ReturnsPlaceholder() {
+        // Given
+        testCard1.setImage("");
+        when(cardRepository.findById(1)).thenReturn(Optional.of(testCard1));
+
+        // When
+        Optional<Card> result = cardService.getCardById(1);
+
+        // Then
+        assertThat(result).isPresent();
+        assertThat(result.get().getImage()).isEqualTo("https://example.com/placeholder.png");
+    }
+
+    @Test
+    @DisplayName("Should keep valid absolute card image URLs")
+    void getCardsByIds_WithAbsoluteImage_KeepsUrl() {
+        // Given
+        testCard1.setImage("https://images.ygoprodeck.com/images/cards/89631139.jpg");
+        List<Integer> cardIds = List.of(1);
+        when(cardRepository.findByIds(cardIds)).thenReturn(List.of(testCard1));
+
+        // When
+        List<Card> result = cardService.getCardsByIds(cardIds);
+
+        // Then
+        assertThat(result.get(0).getImage()).isEqualTo("https://images.ygoprodeck.com/images/cards/89631139.jpg");
+    }
+
+    @Test
+    @DisplayName("Should stream all cards to the consumer and close the stream")
+    void streamAllCards_PassesEachCardAndClosesStream() {
+        // Given
+        AtomicBoolean closed = new AtomicBoolean(false);
+        when(cardRepository.streamAll()).thenReturn(testCards.stream().onClose(() -> closed.set(true)));
+        List<Card> received = new ArrayList<>();
+
+        // When
+        cardService.streamAllCards(received::add);
+
+        // Then
+        assertThat(received).extracting(Card::getId).containsExactly(1, 2, 3);
+        assertThat(closed).isTrue();
+    }
+
+    @Test
+    @DisplayName("Should detach each streamed card after the consumer has written it")
+    void streamAllCards_DetachesEachCardAfterConsumer() {
+        // Given
+        testCard1.setImage("1.jpg");
+        when(cardRepository.streamAll()).thenReturn(testCards.stream());
+        List<Integer> detachedWhenReceived = new ArrayList<>();
+        doAnswer(invocation -> {
+            detachedWhenReceived.add(((Card) invocation.getArgument(0)).getId());
+            return null;
+        }).when(entityManager).detach(any());
+        List<Integer> received = new ArrayList<>();
+
+        // When
+        cardService.streamAllCards(card -> {
+            assertThat(detachedWhenReceived).doesNotContain(card.getId());
+            received.add(card.getId());
+        });
+
+        // Then
+        assertThat(received).containsExactly(1, 2, 3);
+        verify(entityManager).detach(testCard1);
+        verify(entityManager).detach(testCard2);
+        verify(entityManager).detach(testCard3);
+        assertThat(testCard1.getImage()).isEqualTo("1.jpg");
+    }
 }
@@ -1,9 +1,18 @@
 package com.yugioh.service;
 
+import com.yugioh.config.DatabaseReadRetry;
+import com.yugioh.config.DeckRules;
+import com.yugioh.dto.DeckComposition;
 import com.yugioh.dto.DeckSummary;
+import com.yugioh.dto.DeckUpdateRequest;
 import com.yugioh.dto.DeckWithCards;
+import com.yugioh.exception.ConflictException;
+import com.yugioh.exception.ForbiddenException;
+import com.yugioh.exception.InvalidParameterException;
+import com.yugioh.exception.ValidationException;
 import com.yugioh.model.Card;
 import com.yugioh.model.Deck;
+import com.yugioh.model.DeckCard;
 import com.yugioh.repository.CardRepository;
 import com.yugioh.repository.DeckCardRepository;
 import com.yugioh.repository.DeckRepository;
@@ -11,19 +20,29 @@ import org.junit.jupiter.api.BeforeEach;
 import org.junit.jupiter.api.DisplayName;
 import org.junit.jupiter.api.Test;
 import org.junit.jupiter.api.extension.ExtendWith;
+import org.mockito.ArgumentCaptor;
 import org.mockito.InjectMocks;
 import org.mockito.Mock;
+import org.mockito.Spy;
 import org.mockito.junit.jupiter.MockitoExtension;
 import org.springframework.data.domain.Page;
 import org.springframework.data.domain.PageImpl;
 import org.springframework.data.domain.PageRequest;
+import org.springframework.data.domain.Sort;
 
+import java.time.Clock;
+import java.time.Instant;
+import java.time.LocalDateTime;
+import java.time.ZoneOffset;
 import java.util.Arrays;
 import java.util.List;
 import java.util.Optional;
+import java.util.concurrent.atomic.AtomicReference;
 
 import static org.assertj.core.api.Assertions.assertThat;
+import static org.assertj.core.api.Assertions.assertThatThrownBy;
 import static org.mockito.ArgumentMatchers.*;
+import static org.mockito.Mockito.never;
 import static org.mockito.Mockito.verify;
 import static org.mockito.Mockito.when;
 
@@ -40,6 +59,20 @@ class DeckServiceTest {
     @Mock
     private CardRepository cardRepository;
 
+    @Spy
+    private CardImageResolver cardImageResolver =
+        new CardImageResolver("", "https://example.com/placeholder.png");
+
+    @Spy
+    private DatabaseReadRetry databaseReadRetry = new DatabaseReadRetry(0);
+
+    @Spy
+    private DeckRules deckRules = new DeckRules(
+        DeckRules.DEFAULT_MAX_COPIES_PER_CARD, DeckRules.DEFAULT_MIN_DECK_SIZE, DeckRules.DEFAULT_MAX_DECK_SIZE);
+
+    @Spy
+    private Clock clock = Clock.fixed(Instant.parse("2026-03-01T12:00:00Z"), ZoneOffset.UTC);
+
     @InjectMocks
     private DeckService deckService;
 
@@ -101,14 +134,14 @@ class DeckServiceTest {
         List<Integer> cardIds1 = Arrays.asList(1, 2);
         List<Integer> cardIds2 = Arrays.asList(3);
 
-        when(deckRepository.findAllWithFilters(null, null, pageRequest)).thenReturn(deckPage);
+        when(deckRepository.findAllWithFilters(null, null, null, null, pageRequest)).thenReturn(deckPage);
         when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds1);
         when(deckCardRepository.findCardIdsByDeckId(2)).thenReturn(cardIds2);
         when(cardRepository.findByIds(cardIds1)).thenReturn(Arrays.asList(testCard1, testCard2));
         when(cardRepository.findByIds(cardIds2)).thenReturn(Arrays.asList(testCard3));
 
         // When
-        Page<DeckSummary> result = deckService.getAllDecks(page, limit, null, null);
+        Page<DeckSummary> result = deckService.getAllDecks(page, limit, null, null, null, null, null);
 
         // Then
         assertThat(result).isNotNull();
@@ -122,7 +155,7 @@ class DeckServiceTest {
         assertThat(summary1.getTotalCost()).isEqualTo(9); // 5 + 4
         assertThat(summary1.getMostCommonType()).isEqualTo("Dark");
 
-        verify(deckRepository).findAllWithFilters(null, null, pageRequest);
+        verify(deckRepository).findAllWithFilters(null, null, null, null, pageRequest);
     }
 
     @Test
@@ -136,18 +169,18 @@ class DeckServiceTest {
         Page<Deck> deckPage = new PageImpl<>(Arrays.asList(testDeck1), pageRequest, 10);
         List<Integer> cardIds = Arrays.asList(1, 2);
 
-        when(deckRepository.findAllWithFilters(archetype, null, pageRequest)).thenReturn(deckPage);
+        when(deckRepository.findAllWithFilters(archetype, null, null, null, pageRequest)).thenReturn(deckPage);
         when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
         when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
 
         // When
-        Page<DeckSummary> result = deckService.getAllDecks(page, limit, archetype, null);
+        Page<DeckSummary> result = deckService.getAllDecks(page, limit, archetype, null, null, null, null);
 
         // Then
         assertThat(result).isNotNull();
         assertThat(result.getContent()).hasSize(1);
         assertThat(result.getContent().get(0).getArchetype()).isEqualTo(archetype);
-        verify(deckRepository).findAllWithFilters(archetype, null, pageRequest);
+        verify(deckRepository).findAllWithFilters(archetype, null, null, null, pageRequest);
     }
 
     @Test
@@ -161,17 +194,171 @@ class DeckServiceTest {
         Page<Deck> deckPage = new PageImpl<>(Arrays.asList(testDeck1, testDeck2), pageRequest, 30);
         List<Integer> cardIds = Arrays.asList(1);
 
-        when(deckRepository.findAllWithFilters(null, presetOnly, pageRequest)).thenReturn(deckPage);
+        when(deckRepository.findAllWithFilters(null, presetOnly, null, null, pageRequest)).thenReturn(deckPage);
         when(deckCardRepository.findCardIdsByDeckId(anyInt())).thenReturn(cardIds);
         when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1));
 
         // When
-        Page<DeckSummary> result = deckService.getAllDecks(page, limit, null, presetOnly);
+        Page<DeckSummary> result = deckService.getAllDecks(page, limit, null, presetOnly, null, null, null);
 
         // Then
         assertThat(result).isNotNull();
         assertThat(result.getContent()).allMatch(DeckSummary::getIsPreset);
-        verify(deckRepository).findAllWithFilters(null, presetOnly, pageRequest);
+        verify(deckRepository).findAllWithFilters(null, presetOnly, null, null, pageRequest);
+    }
+
+    @Test
+    @DisplayName("Should order decks by ascending total cost")
+    void getAllDecks_WithCostSort_UsesAscendingCostQuery() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        Page<Deck> deckPage = new PageImpl<>(List.of(testDeck2, testDeck1), pageRequest, 2);
+        when(deckRepository.findAllWithFiltersOrderByTotalCostAsc("Blue-Eyes", null, null, null, pageRequest)).thenReturn(deckPage);
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, "Blue-Eyes", null, "cost", null, null);
+
+        // Then
+        assertThat(result.getContent()).extracting(DeckSummary::getId).containsExactly(2, 1);
+        verify(deckRepository, never()).findAllWithFilters(any(), any(), any(), any(), any());
+    }
+
+    @Test
+    @DisplayName("Should order decks by descending total cost")
+    void getAllDecks_WithDescendingCostSort_UsesDescendingCostQuery() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        Page<Deck> deckPage = new PageImpl<>(List.of(testDeck1), pageRequest, 1);
+        when(deckRepository.findAllWithFiltersOrderByTotalCostDesc(null, true, null, null, pageRequest)).thenReturn(deckPage);
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, true, " -cost ", null, null);
+
+        // Then
+        assertThat(result.getContent()).extracting(DeckSummary::getId).containsExactly(1);
+    }
+
+    @Test
+    @DisplayName("Should order decks by descending total power")
+    void getAllDecks_WithDescendingPowerSort_UsesDescendingPowerQuery() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        Page<Deck> deckPage = new PageImpl<>(List.of(testDeck2, testDeck1), pageRequest, 2);
+        when(deckRepository.findAllWithFiltersOrderByTotalPowerDesc(null, null, 10, null, pageRequest)).thenReturn(deckPage);
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, null, "-power", 10, null);
+
+        // Then
+        assertThat(result.getContent()).extracting(DeckSummary::getId).containsExactly(2, 1);
+        verify(deckRepository, never()).findAllWithFiltersOrderByTotalCostDesc(any(), any(), any(), any(), any());
+    }
+
+    @Test
+    @DisplayName("Should order decks by ascending total power")
+    void getAllDecks_WithPowerSort_UsesAscendingPowerQuery() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        Page<Deck> deckPage = new PageImpl<>(List.of(testDeck1), pageRequest, 1);
+        when(deckRepository.findAllWithFiltersOrderByTotalPowerAsc("Dragon", null, null, null, pageRequest)).thenReturn(deckPage);
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, "Dragon", null, "power", null, null);
+
+        // Then
+        assertThat(result.getContent()).extracting(DeckSummary::getId).containsExactly(1);
+    }
+
+    @Test
+    @DisplayName("Should pass the total cost range to the listing query")
+    void getAllDecks_WithCostRange_FiltersOnTotalCost() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        List<Integer> cardIds = Arrays.asList(1, 2);
+        when(deckRepository.findAllWithFilters(null, null, 5, 10, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck1), pageRequest, 1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, null, null, 5, 10);
+
+        // Then
+        assertThat(result.getContent()).extracting(DeckSummary::getTotalCost).containsExactly(9);
+        assertThat(result.getTotalElements()).isEqualTo(1L);
+    }
+
+    @Test
+    @DisplayName("Should reject a minimum cost above the maximum")
+    void getAllDecks_WithInvertedCostRange_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> deckService.getAllDecks(1, 20, null, null, null, 50, 30))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'min_cost' must not exceed 'max_cost', got 50 > 30");
+        assertThatThrownBy(() -> deckService.countDecks(null, null, 50, 30))
+            .isInstanceOf(InvalidParameterException.class);
+        verify(deckRepository, never()).findAllWithFilters(any(), any(), any(), any(), any());
+    }
+
+    @Test
+    @DisplayName("Should reject sort keys outside the whitelist")
+    void getAllDecks_WithUnknownSort_ThrowsInvalidParameter() {
+        assertThatThrownBy(() -> deckService.getAllDecks(1, 20, null, null, "name; DROP TABLE decks", null, null))
+            .isInstanceOf(InvalidParameterException.class)
+            .hasMessage("Parameter 'sort' must be one of [cost, -cost, power, -power], got 'name; DROP TABLE decks'");
+        verify(deckRepository, never()).findAllWithFilters(any(), any(), any(), any(), any());
+    }
+
+    @Test
+    @DisplayName("Should load batched decks in request order with one card lookup")
+    void getDecksByIds_SharesSingleCardLookup() {
+        // Given
+        when(deckRepository.findAllById(List.of(2, 1, 99))).thenReturn(List.of(testDeck1, testDeck2));
+        when(deckCardRepository.findByDeckIdInOrderByDeckIdAscPositionAsc(anyList())).thenReturn(List.of(
+            deckCard(1, 1, 1), deckCard(1, 2, 2), deckCard(2, 2, 1), deckCard(2, 3, 2)));
+        when(cardRepository.findByIds(List.of(1, 2, 3))).thenReturn(Arrays.asList(testCard1, testCard2, testCard3));
+
+        // When
+        List<DeckWithCards> result = deckService.getDecksByIds(List.of(2, 1, 2, 99));
+
+        // Then
+        assertThat(result).extracting(DeckWithCards::getId).containsExactly(2, 1);
+        assertThat(result.get(0).getCards()).extracting(Card::getId).containsExactly(2, 3);
+        assertThat(result.get(1).getCards()).extracting(Card::getId).containsExactly(1, 2);
+        assertThat(result.get(1).getTotalCost()).isEqualTo(9);
+        verify(cardRepository).findByIds(anyList());
+        verify(deckCardRepository, never()).findCardIdsByDeckId(anyInt());
+    }
+
+    private static DeckCard deckCard(int deckId, int cardId, int position) {
+        DeckCard deckCard = new DeckCard();
+        deckCard.setDeckId(deckId);
+        deckCard.setCardId(cardId);
+        deckCard.setPosition(position);
+        return deckCard;
+    }
+
+    @Test
+    @DisplayName("Should get a deck's card IDs without loading cards")
+    void getDeckCardIds_WhenDeckExists_ReturnsIdsInPositionOrder() {
+        // Given
+        when(deckRepository.existsById(1)).thenReturn(true);
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of(3, 1, 1));
+
+        // When
+        Optional<List<Integer>> result = deckService.getDeckCardIds(1);
+
+        // Then
+        assertThat(result).contains(List.of(3, 1, 1));
+        verify(cardRepository, never()).findByIds(any());
+    }
+
+    @Test
+    @DisplayName("Should return empty card IDs for a missing deck")
+    void getDeckCardIds_WhenDeckNotExists_ReturnsEmpty() {
+        when(deckRepository.existsById(999)).thenReturn(false);
+
+        assertThat(deckService.getDeckCardIds(999)).isEmpty();
+        verify(deckCardRepository, never()).findCardIdsByDeckId(any());
     }
 
     @Test
@@ -197,6 +384,7 @@ class DeckServiceTest {
         assertThat(deckWithCards.getCards()).hasSize(2);
         assertThat(deckWithCards.getTotalCost()).isEqualTo(9); // 5 + 4
         assertThat(deckWithCards.getMostCommonType()).isEqualTo("Dark");
+        assertThat(deckWithCards.getCards()).allMatch(card -> "https://example.com/placeholder.png".equals(card.getImage()));
         verify(deckRepository).findById(deckId);
         verify(deckCardRepository).findCardIdsByDeckId(deckId);
         verify(cardRepository).findByIds(cardIds);
@@ -217,6 +405,243 @@ class DeckServiceTest {
         verify(deckRepository).findById(deckId);
     }
 
+    @Test
+    @DisplayName("Should return every copy of a card that appears more than once in a deck")
+    void getDeckById_WithDuplicateCards_ReturnsEveryCopy() {
+        // Given
+        Integer deckId = 1;
+        List<Integer> cardIds = Arrays.asList(1, 1, 1, 3);
+
+        when(deckRepository.findById(deckId)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(deckId)).thenReturn(cardIds);
+        when(cardRepository.findByIds(List.of(1, 3))).thenReturn(Arrays.asList(testCard1, testCard3));
+
+        // When
+        Optional<DeckWithCards> result = deckService.getDeckById(deckId);
+
+        // Then
+        assertThat(result).isPresent();
+        assertThat(result.get().getCards()).extracting(Card::getId).containsExactly(1, 1, 1, 3);
+        assertThat(result.get().getTotalCost()).isEqualTo(17); // 3 * 5 + 2
+    }
+
+    @Test
+    @DisplayName("Should count every copy in the deck's type composition")
+    void getDeckById_WithDuplicateCards_ComputesComposition() {
+        // Given: type strings as they appear in the card data
+        testCard1.setType("Normal Monster");
+        testCard2.setType("Effect Monster");
+        testCard3.setType("Spell Card");
+        Card trap = new Card();
+        trap.setId(4);
+        trap.setType("Trap Card");
+        trap.setCost(1);
+        List<Integer> cardIds = Arrays.asList(1, 1, 3, 4, 2, 2);
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
+        when(cardRepository.findByIds(List.of(1, 3, 4, 2))).thenReturn(Arrays.asList(testCard1, testCard2, testCard3, trap));
+
+        // When
+        DeckComposition composition = deckService.getDeckById(1).orElseThrow().getComposition();
+
+        // Then
+        assertThat(composition.getMonsters()).isEqualTo(4);
+        assertThat(composition.getSpells()).isEqualTo(1);
+        assertThat(composition.getTraps()).isEqualTo(1);
+        assertThat(composition.getMonsterRatio()).isEqualTo(0.67);
+    }
+
+    @Test
+    @DisplayName("Should report an empty composition for a deck with no cards")
+    void getDeckById_WithNoCards_ReturnsZeroComposition() {
+        // Given
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of());
+        when(cardRepository.findByIds(List.of())).thenReturn(List.of());
+
+        // When
+        DeckComposition composition = deckService.getDeckById(1).orElseThrow().getComposition();
+
+        // Then
+        assertThat(composition.getMonsters()).isZero();
+        assertThat(composition.getMonsterRatio()).isEqualTo(0.0);
+    }
+
+    @Test
+    @DisplayName("Should return deck cards in position order rather than card ID order")
+    void getDeckById_ReturnsCardsInPositionOrder() {
+        // Given
+        Integer deckId = 1;
+        List<Integer> cardIds = Arrays.asList(3, 1, 2, 1);
+
+        when(deckRepository.findById(deckId)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(deckId)).thenReturn(cardIds);
+        when(cardRepository.findByIds(List.of(3, 1, 2))).thenReturn(Arrays.asList(testCard1, testCard2, testCard3));
+
+        // When
+        Optional<DeckWithCards> result = deckService.getDeckById(deckId);
+
+        // Then
+        assertThat(result.get().getCards()).extracting(Card::getId).containsExactly(3, 1, 2, 1);
+    }
+
+    @Test
+    @DisplayName("Should count every copy of a card in deck summaries")
+    void getAllDecks_WithDuplicateCards_CountsEveryCopy() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        List<Integer> cardIds = Arrays.asList(2, 2, 1);
+        when(deckRepository.findAllWithFilters(null, null, null, null, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck1), pageRequest, 1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
+        when(cardRepository.findByIds(List.of(2, 1))).thenReturn(Arrays.asList(testCard1, testCard2));
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, null, null, null, null);
+
+        // Then
+        DeckSummary summary = result.getContent().get(0);
+        assertThat(summary.getCardCount()).isEqualTo(3);
+        assertThat(summary.getTotalCost()).isEqualTo(13); // 4 + 4 + 5
+    }
+
+    @Test
+    @DisplayName("Should mark a preset deck within the rules as legal")
+    void getAllDecks_WithPresetDeck_IsLegal() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        List<Integer> cardIds = Arrays.asList(1, 1, 1, 2, 2, 3);
+        when(deckRepository.findAllWithFilters(null, true, null, null, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck1), pageRequest, 1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
+        when(cardRepository.findByIds(List.of(1, 2, 3))).thenReturn(Arrays.asList(testCard1, testCard2, testCard3));
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, true, null, null, null);
+
+        // Then
+        DeckSummary summary = result.getContent().get(0);
+        assertThat(summary.getIsPreset()).isTrue();
+        assertThat(summary.getIsLegal()).isTrue();
+        assertThat(summary.getLegalityReason()).isNull();
+    }
+
+    @Test
+    @DisplayName("Should mark a deck over its cost budget as illegal")
+    void getAllDecks_OverCostBudget_IsIllegal() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        testDeck2.setMaxCost(8);
+        List<Integer> cardIds = Arrays.asList(1, 2);
+        when(deckRepository.findAllWithFilters(null, null, null, null, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck2), pageRequest, 1));
+        when(deckCardRepository.findCardIdsByDeckId(2)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, null, null, null, null);
+
+        // Then
+        DeckSummary summary = result.getContent().get(0);
+        assertThat(summary.getIsLegal()).isFalse();
+        assertThat(summary.getLegalityReason()).isEqualTo("Total cost 9 exceeds budget of 8");
+    }
+
+    @Test
+    @DisplayName("Should mark a deck with too many copies of a card as illegal")
+    void getAllDecks_TooManyCopies_IsIllegal() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        List<Integer> cardIds = Arrays.asList(3, 3, 3, 3);
+        when(deckRepository.findAllWithFilters(null, null, null, null, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck2), pageRequest, 1));
+        when(deckCardRepository.findCardIdsByDeckId(2)).thenReturn(cardIds);
+        when(cardRepository.findByIds(List.of(3))).thenReturn(List.of(testCard3));
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, null, null, null, null);
+
+        // Then
+        DeckSummary summary = result.getContent().get(0);
+        assertThat(summary.getIsLegal()).isFalse();
+        assertThat(summary.getLegalityReason()).isEqualTo("Card 3 has 4 copies, max is 3");
+    }
+
+    @Test
+    @DisplayName("Should mark an under-sized user deck as illegal")
+    void getAllDecks_UserDeckUnderMinSize_IsIllegal() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(0, 20);
+        testDeck2.setIsPreset(false);
+        List<Integer> cardIds = Arrays.asList(1, 2);
+        when(deckRepository.findAllWithFilters(null, null, null, null, pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck2), pageRequest, 1));
+        when(deckCardRepository.findCardIdsByDeckId(2)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
+
+        // When
+        Page<DeckSummary> result = deckService.getAllDecks(1, 20, null, null, null, null, null);
+
+        // Then
+        DeckSummary summary = result.getContent().get(0);
+        assertThat(summary.getIsLegal()).isFalse();
+        assertThat(summary.getLegalityReason()).isEqualTo("Deck has 2 cards, min is 40");
+    }
+
+    @Test
+    @DisplayName("Should skip card IDs that no longer exist")
+    void getDeckById_WithMissingCard_SkipsIt() {
+        // Given
+        Integer deckId = 1;
+        List<Integer> cardIds = Arrays.asList(1, 99);
+
+        when(deckRepository.findById(deckId)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(deckId)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(List.of(testCard1));
+
+        // When
+        Optional<DeckWithCards> result = deckService.getDeckById(deckId);
+
+        // Then
+        assertThat(result.get().getCards()).extracting(Card::getId).containsExactly(1);
+        assertThat(result.get().getTotalCost()).isEqualTo(5);
+    }
+
+    @Test
+    @DisplayName("Should get a deck by case-insensitive name")
+    void getDeckByName_WhenSingleMatch_ReturnsDeck() {
+        // Given
+        when(deckRepository.findByNameIgnoreCaseOrderById("yugi's deck")).thenReturn(List.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of(1));
+        when(cardRepository.findByIds(List.of(1))).thenReturn(List.of(testCard1));
+
+        // When
+        Optional<DeckWithCards> result = deckService.getDeckByName(" yugi's deck ");
+
+        // Then
+        assertThat(result).isPresent();
+        assertThat(result.get().getName()).isEqualTo("Yugi's Deck");
+        assertThat(result.get().getCards()).extracting(Card::getId).containsExactly(1);
+    }
+
+    @Test
+    @DisplayName("Should return empty when no deck has the name")
+    void getDeckByName_WhenNoMatch_ReturnsEmpty() {
+        when(deckRepository.findByNameIgnoreCaseOrderById("Nobody's Deck")).thenReturn(List.of());
+
+        assertThat(deckService.getDeckByName("Nobody's Deck")).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should throw a conflict when the name matches several decks")
+    void getDeckByName_WhenAmbiguous_ThrowsConflict() {
+        when(deckRepository.findByNameIgnoreCaseOrderById("yugi's deck")).thenReturn(List.of(testDeck1, testDeck2));
+
+        assertThatThrownBy(() -> deckService.getDeckByName("yugi's deck"))
+            .isInstanceOf(ConflictException.class)
+            .hasMessage("Deck name 'yugi's deck' matches 2 decks");
+    }
+
     @Test
     @DisplayName("Should calculate most common type correctly for monsters")
     void calculateMostCommonType_WithMonsters_ReturnsMostCommonAttribute() {
@@ -287,15 +712,15 @@ class DeckServiceTest {
         boolean presetOnly = false;
         long countBefore = 25L;
 
-        when(deckRepository.countDecksBeforeId(deckId, archetype, presetOnly)).thenReturn(countBefore);
+        when(deckRepository.countDecksBeforeId(deckId, archetype, presetOnly, null, null)).thenReturn(countBefore);
 
         // When
-        int result = deckService.calculatePageFromDeckId(deckId, limit, archetype, presetOnly);
+        int result = deckService.calculatePageFromDeckId(deckId, limit, archetype, presetOnly, null, null);
 
         // Then
         // 25 decks before, 20 per page = page 2 (1-based)
         assertThat(result).isEqualTo(2);
-        verify(deckRepository).countDecksBeforeId(deckId, archetype, presetOnly);
+        verify(deckRepository).countDecksBeforeId(deckId, archetype, presetOnly, null, null);
     }
 
     @Test
@@ -308,15 +733,15 @@ class DeckServiceTest {
         boolean presetOnly = true;
         long countBefore = 5L;
 
-        when(deckRepository.countDecksBeforeId(deckId, archetype, presetOnly)).thenReturn(countBefore);
+        when(deckRepository.countDecksBeforeId(deckId, archetype, presetOnly, null, null)).thenReturn(countBefore);
 
         // When
-        int result = deckService.calculatePageFromDeckId(deckId, limit, archetype, presetOnly);
+        int result = deckService.calculatePageFromDeckId(deckId, limit, archetype, presetOnly, null, null);
 
         // Then
         // 5 decks before, 20 per page = page 1 (1-based)
         assertThat(result).isEqualTo(1);
-        verify(deckRepository).countDecksBeforeId(deckId, archetype, presetOnly);
+        verify(deckRepository).countDecksBeforeId(deckId, archetype, presetOnly, null, null);
     }
 
     @Test
@@ -329,12 +754,12 @@ class DeckServiceTest {
         Page<Deck> deckPage = new PageImpl<>(Arrays.asList(testDeck1), pageRequest, 10);
         List<Integer> emptyCardIds = List.of();
 
-        when(deckRepository.findAllWithFilters(null, null, pageRequest)).thenReturn(deckPage);
+        when(deckRepository.findAllWithFilters(null, null, null, null, pageRequest)).thenReturn(deckPage);
         when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(emptyCardIds);
         when(cardRepository.findByIds(emptyCardIds)).thenReturn(List.of());
 
         // When
-        Page<DeckSummary> result = deckService.getAllDecks(page, limit, null, null);
+        Page<DeckSummary> result = deckService.getAllDecks(page, limit, null, null, null, null, null);
 
         // Then
         assertThat(result).isNotNull();
@@ -511,4 +936,302 @@ class DeckServiceTest {
         assertThat(result.get().getMostCommonType()).isEqualTo("Trap");
     }
 
+    @Test
+    @DisplayName("Should get decks that use a card")
+    void getDecksUsingCard_ReturnsDeckSummaries() {
+        // Given
+        Integer cardId = 1;
+        int page = 1;
+        int limit = 20;
+        PageRequest pageRequest = PageRequest.of(page - 1, limit, Sort.by("id").ascending());
+        Page<Deck> deckPage = new PageImpl<>(Arrays.asList(testDeck1), pageRequest, 1);
+        List<Integer> cardIds = Arrays.asList(1, 2);
+
+        when(deckRepository.findDecksContainingCard(cardId, pageRequest)).thenReturn(deckPage);
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
+
+        // When
+        Page<DeckSummary> result = deckService.getDecksUsingCard(cardId, page, limit);
+
+        // Then
+        assertThat(result.getContent()).hasSize(1);
+        assertThat(result.getTotalElements()).isEqualTo(1L);
+        DeckSummary summary = result.getContent().get(0);
+        assertThat(summary.getName()).isEqualTo("Yugi's Deck");
+        assertThat(summary.getTotalCost()).isEqualTo(9);
+        verify(deckRepository).findDecksContainingCard(cardId, pageRequest);
+    }
+
+    @Test
+    @DisplayName("Should get characters whose decks use a card")
+    void getCharactersUsingCard_ReturnsCharacterNames() {
+        // Given
+        Integer cardId = 1;
+        when(deckRepository.findCharacterNamesUsingCard(cardId)).thenReturn(List.of("Seto Kaiba", "Yugi Muto"));
+
+        // When
+        List<String> result = deckService.getCharactersUsingCard(cardId);
+
+        // Then
+        assertThat(result).containsExactly("Seto Kaiba", "Yugi Muto");
+        verify(deckRepository).findCharacterNamesUsingCard(cardId);
+    }
+
+    @Test
+    @DisplayName("Should clone a deck with its cards into a non-preset deck")
+    @SuppressWarnings("unchecked")
+    void cloneDeck_WhenSourceExists_CopiesDeckAndCards() {
+        // Given
+        DeckCard card1 = new DeckCard();
+        card1.setDeckId(1);
+        card1.setCardId(1);
+        card1.setPosition(1);
+        DeckCard card2 = new DeckCard();
+        card2.setDeckId(1);
+        card2.setCardId(2);
+        card2.setPosition(2);
+        List<Integer> cardIds = Arrays.asList(1, 2);
+
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckRepository.existsByName("Yugi's Deck (Copy)")).thenReturn(true);
+        when(deckRepository.existsByName("Yugi's Deck (Copy 2)")).thenReturn(false);
+        AtomicReference<Deck> savedDeck = new AtomicReference<>();
+        when(deckRepository.save(any(Deck.class))).thenAnswer(invocation -> {
+            Deck saved = invocation.getArgument(0);
+            saved.setId(5);
+            savedDeck.set(saved);
+            return saved;
+        });
+        when(deckRepository.findById(5)).thenAnswer(invocation -> Optional.of(savedDeck.get()));
+        when(deckCardRepository.findByDeckIdOrderByPosition(1)).thenReturn(Arrays.asList(card1, card2));
+        when(deckCardRepository.findCardIdsByDeckId(5)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
+        testCard1.setImage("1.jpg");
+
+        // When
+        Optional<DeckWithCards> result = deckService.cloneDeck(1);
+
+        // Then
+        assertThat(result).isPresent();
+        DeckWithCards clone = result.get();
+        assertThat(clone.getId()).isEqualTo(5);
+        assertThat(clone.getName()).isEqualTo("Yugi's Deck (Copy 2)");
+        assertThat(clone.getIsPreset()).isFalse();
+        assertThat(clone.getCharacterName()).isEqualTo("Yugi Muto");
+        assertThat(savedDeck.get().getCreatedAt()).isEqualTo(LocalDateTime.of(2026, 3, 1, 12, 0));
+        assertThat(clone.getArchetype()).isEqualTo("Dark Magician");
+        assertThat(clone.getCards()).hasSize(2);
+        assertThat(testCard1.getImage()).isEqualTo("1.jpg");
+
+        ArgumentCaptor<List<DeckCard>> copiesCaptor = ArgumentCaptor.forClass(List.class);
+        verify(deckCardRepository).saveAll(copiesCaptor.capture());
+        assertThat(copiesCaptor.getValue()).extracting(DeckCard::getDeckId).containsOnly(5);
+        assertThat(copiesCaptor.getValue()).extracting(DeckCard::getCardId).containsExactly(1, 2);
+        assertThat(copiesCaptor.getValue()).extracting(DeckCard::getPosition).containsExactly(1, 2);
+    }
+
+    @Test
+    @DisplayName("Should return empty when cloning a deck that does not exist")
+    void cloneDeck_WhenSourceNotExists_ReturnsEmpty() {
+        // Given
+        when(deckRepository.findById(999)).thenReturn(Optional.empty());
+
+        // When
+        Optional<DeckWithCards> result = deckService.cloneDeck(999);
+
+        // Then
+        assertThat(result).isEmpty();
+        verify(deckRepository, never()).save(any(Deck.class));
+    }
+
+    @Test
+    @DisplayName("Should update only the provided deck fields")
+    void updateDeck_WithName_LeavesOtherFieldsAndCards() {
+        // Given
+        testDeck1.setIsPreset(false);
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName(" Yugi's Fixed Deck ");
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of(1));
+        when(cardRepository.findByIds(List.of(1))).thenReturn(List.of(testCard1));
+
+        // When
+        Optional<DeckWithCards> result = deckService.updateDeck(1, update);
+
+        // Then
+        assertThat(result).isPresent();
+        assertThat(result.get().getName()).isEqualTo("Yugi's Fixed Deck");
+        assertThat(result.get().getDescription()).isEqualTo("Yugi's main deck");
+        assertThat(result.get().getMaxCost()).isEqualTo(100);
+        verify(deckRepository).save(testDeck1);
+        verify(deckCardRepository, never()).saveAll(any());
+    }
+
+    @Test
+    @DisplayName("Should shorten a long source name so the copy suffix fits the name column")
+    void cloneDeck_WithLongName_TruncatesBeforeSuffix() {
+        // Given
+        testDeck1.setName("Y".repeat(255));
+        String copyName = "Y".repeat(248) + " (Copy)";
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckRepository.existsByName(copyName)).thenReturn(true);
+        when(deckRepository.existsByName("Y".repeat(246) + " (Copy 2)")).thenReturn(false);
+        when(deckRepository.save(any(Deck.class))).thenAnswer(invocation -> {
+            Deck saved = invocation.getArgument(0);
+            saved.setId(5);
+            return saved;
+        });
+
+        // When
+        deckService.cloneDeck(1);
+
+        // Then
+        ArgumentCaptor<Deck> savedCaptor = ArgumentCaptor.forClass(Deck.class);
+        verify(deckRepository).save(savedCaptor.capture());
+        assertThat(savedCaptor.getValue().getName()).hasSize(255).endsWith("Y (Copy 2)");
+    }
+
+    @Test
+    @DisplayName("Should leave the stored card image unchanged after an update")
+    void updateDeck_ResolvesImagesWithoutChangingCards() {
+        // Given
+        testDeck1.setIsPreset(false);
+        testCard1.setImage("1.jpg");
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName("Yugi's Fixed Deck");
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of(1));
+        when(cardRepository.findByIds(List.of(1))).thenReturn(List.of(testCard1));
+
+        // When
+        Optional<DeckWithCards> result = deckService.updateDeck(1, update);
+
+        // Then
+        assertThat(result).isPresent();
+        assertThat(result.get().getCards().get(0).getImage()).isEqualTo("https://example.com/placeholder.png");
+        assertThat(testCard1.getImage()).isEqualTo("1.jpg");
+    }
+
+    @Test
+    @DisplayName("Should reject a max cost below the deck's total cost")
+    void updateDeck_WithMaxCostBelowTotal_ThrowsValidation() {
+        // Given
+        testDeck1.setIsPreset(false);
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setMaxCost(8);
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of(1, 2));
+        when(cardRepository.findByIds(List.of(1, 2))).thenReturn(List.of(testCard1, testCard2));
+
+        // When / Then
+        assertThatThrownBy(() -> deckService.updateDeck(1, update))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Deck total cost 9 exceeds new max cost 8");
+        verify(deckRepository, never()).save(any(Deck.class));
+    }
+
+    @Test
+    @DisplayName("Should refuse to modify a preset deck")
+    void updateDeck_WhenPreset_ThrowsForbidden() {
+        // Given
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName("Renamed");
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+
+        // When / Then
+        assertThatThrownBy(() -> deckService.updateDeck(1, update))
+            .isInstanceOf(ForbiddenException.class)
+            .hasMessage("Deck 1 is a preset deck and cannot be modified");
+        verify(deckRepository, never()).save(any(Deck.class));
+    }
+
+    @Test
+    @DisplayName("Should reject a blank name")
+    void updateDeck_WithBlankName_ThrowsValidation() {
+        // Given
+        testDeck1.setIsPreset(false);
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName("  ");
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+
+        // When / Then
+        assertThatThrownBy(() -> deckService.updateDeck(1, update))
+            .isInstanceOf(ValidationException.class)
+            .hasMessage("Field 'name' must not be blank");
+    }
+
+    @Test
+    @DisplayName("Should reject renaming a deck to a name another deck has")
+    void updateDeck_WithTakenName_ThrowsConflict() {
+        // Given
+        testDeck1.setIsPreset(false);
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName(" Kaiba's Deck ");
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckRepository.existsByName("Kaiba's Deck")).thenReturn(true);
+
+        // When / Then
+        assertThatThrownBy(() -> deckService.updateDeck(1, update))
+            .isInstanceOf(ConflictException.class)
+            .hasMessage("Deck name 'Kaiba's Deck' is already taken");
+        verify(deckRepository, never()).save(any(Deck.class));
+    }
+
+    @Test
+    @DisplayName("Should allow keeping the deck's current name")
+    void updateDeck_WithSameName_SkipsDuplicateCheck() {
+        // Given
+        testDeck1.setIsPreset(false);
+        DeckUpdateRequest update = new DeckUpdateRequest();
+        update.setName("Yugi's Deck");
+        when(deckRepository.findById(1)).thenReturn(Optional.of(testDeck1));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(List.of());
+        when(cardRepository.findByIds(List.of())).thenReturn(List.of());
+
+        // When
+        Optional<DeckWithCards> result = deckService.updateDeck(1, update);
+
+        // Then
+        assertThat(result).isPresent();
+        verify(deckRepository, never()).existsByName(any());
+        verify(deckRepository).save(testDeck1);
+    }
+
+    @Test
+    @DisplayName("Should return empty when updating a deck that does not exist")
+    void updateDeck_WhenDeckNotExists_ReturnsEmpty() {
+        when(deckRepository.findById(999)).thenReturn(Optional.empty());
+
+        assertThat(deckService.updateDeck(999, new DeckUpdateRequest())).isEmpty();
+    }
+
+    @Test
+    @DisplayName("Should search decks by name with pagination")
+    void searchDecksByName_WithQuery_ReturnsMatchingSummaries() {
+        // Given
+        PageRequest pageRequest = PageRequest.of(1, 10);
+        List<Integer> cardIds = Arrays.asList(1, 2);
+        when(deckRepository.searchByName("yugi", pageRequest))
+            .thenReturn(new PageImpl<>(List.of(testDeck1), pageRequest, 11));
+        when(deckCardRepository.findCardIdsByDeckId(1)).thenReturn(cardIds);
+        when(cardRepository.findByIds(cardIds)).thenReturn(Arrays.asList(testCard1, testCard2));
+
+        // When
+        Page<DeckSummary> result = deckService.searchDecksByName("  yugi ", 2, 10);
+
+        // Then
+        assertThat(result.getContent()).hasSize(1);
+        assertThat(result.getContent().get(0).getName()).isEqualTo("Yugi's Deck");
+        assertThat(result.getTotalElements()).isEqualTo(11L);
+        assertThat(result.getTotalPages()).isEqualTo(2);
+    }
+
+    @Test
+    @DisplayName("Should return an empty page for a blank deck search")
+    void searchDecksByName_WithBlankQuery_ReturnsEmpty() {
+        assertThat(deckService.searchDecksByName(null, 1, 20)).isEmpty();
+        assertThat(deckService.searchDecksByName("   ", 1, 20).getTotalElements()).isZero();
+        verify(deckRepository, never()).searchByName(anyString(), any());
+    }
 }
@@ -0,0 +1,7 @@
+-- Every card carries updated_at so GET /cards/{id} can answer If-Modified-Since
+UPDATE cards SET updated_at = COALESCE(created_at, NOW()) WHERE updated_at IS NULL;
+UPDATE cards SET created_at = updated_at WHERE created_at IS NULL;
+ALTER TABLE cards
+  ALTER COLUMN created_at SET DEFAULT NOW(),
+  ALTER COLUMN updated_at SET DEFAULT NOW(),
+  ALTER COLUMN updated_at SET NOT NULL;
@@ -0,0 +1,5 @@
+-- cards.updated_at is served as Last-Modified and read back as UTC, so stamp it
+-- in UTC whatever the writing session's time zone is
+ALTER TABLE cards
+  ALTER COLUMN created_at SET DEFAULT (NOW() AT TIME ZONE 'UTC'),
+  ALTER COLUMN updated_at SET DEFAULT (NOW() AT TIME ZONE 'UTC');
@@ -12,6 +12,7 @@ Usage:
     python db_manager.py reset-and-seed   # reset-db + migrate + seed
     python db_manager.py clear-all     # Truncate tables (keep schema)
     python db_manager.py clear-table <name>
+    python db_manager.py clear-all --dry-run   # Show what would be cleared, change nothing
     python db_manager.py status        # Counts only
 """
 
@@ -33,6 +34,12 @@ DB_SETTINGS = {
 
 SCRIPT_DIR = Path(__file__).parent
 
+CLEARABLE_TABLES = ("cards", "decks", "deck_cards")
+# TRUNCATE ... CASCADE also empties the tables that reference the one cleared
+CASCADES = {"cards": ["deck_cards"], "decks": ["deck_cards"], "deck_cards": []}
+ID_TABLES = {"cards", "decks"}
+SAMPLE_SIZE = 5
+
 
 def get_connection():
     try:
@@ -61,8 +68,32 @@ def migrate():
     print("[OK] Migrations completed")
 
 
-def clear_all_tables():
-    """Clear all data but keep the schema."""
+def preview_tables(cur, tables):
+    """Row count and the first few IDs of each table that a clear would empty."""
+    report = {}
+    for table in tables:
+        cur.execute(f"SELECT COUNT(*) FROM {table};")
+        entry = {"count": cur.fetchone()[0]}
+        if table in ID_TABLES:
+            cur.execute(f"SELECT id FROM {table} ORDER BY id LIMIT {SAMPLE_SIZE};")
+            entry["sample_ids"] = [row[0] for row in cur.fetchall()]
+        report[table] = entry
+    return report
+
+
+def print_clear_report(report, dry_run: bool):
+    """Print the same summary for a dry run and a real clear."""
+    print("[DRY RUN] Would clear:" if dry_run else "Clearing:")
+    for table, entry in report.items():
+        line = f"  {table}: {entry['count']} rows"
+        if entry.get("sample_ids"):
+            more = ", ..." if entry["count"] > len(entry["sample_ids"]) else ""
+            line += f" (ids {', '.join(str(i) for i in entry['sample_ids'])}{more})"
+        print(line)
+
+
+def clear_all_tables(dry_run: bool = False):
+    """Clear all data but keep the schema. With dry_run, only report what would go."""
     conn = get_connection()
     with conn, conn.cursor() as cur:
         cur.execute(
@@ -76,13 +107,18 @@ def clear_all_tables():
         if not existing:
             print("[ERROR] Tables do not exist. Run migrations first.", file=sys.stderr)
             sys.exit(1)
-        cur.execute(f"TRUNCATE TABLE {', '.join(existing)} RESTART IDENTITY CASCADE;")
-    print(f"[OK] Cleared {', '.join(existing)}")
-
-
-def clear_table(table_name: str):
-    """Clear a single table."""
-    if table_name not in {"cards", "decks", "deck_cards"}:
+        report = preview_tables(cur, existing)
+        print_clear_report(report, dry_run)
+        if not dry_run:
+            cur.execute(f"TRUNCATE TABLE {', '.join(existing)} RESTART IDENTITY CASCADE;")
+    if not dry_run:
+        print(f"[OK] Cleared {', '.join(existing)}")
+    return {"dry_run": dry_run, "tables": report}
+
+
+def clear_table(table_name: str, dry_run: bool = False):
+    """Clear a single table (and, by cascade, its dependents). With dry_run, only report."""
+    if table_name not in CLEARABLE_TABLES:
         print(f"[ERROR] Unsupported table '{table_name}'", file=sys.stderr)
         sys.exit(1)
     conn = get_connection()
@@ -94,8 +130,13 @@ def clear_table(table_name: str):
         if not cur.fetchone()[0]:
             print(f"[ERROR] Table '{table_name}' does not exist. Run migrations first.", file=sys.stderr)
             sys.exit(1)
-        cur.execute(f"TRUNCATE TABLE {table_name} RESTART IDENTITY CASCADE;")
-    print(f"[OK] Cleared {table_name}")
+        report = preview_tables(cur, [table_name] + CASCADES[table_name])
+        print_clear_report(report, dry_run)
+        if not dry_run:
+            cur.execute(f"TRUNCATE TABLE {table_name} RESTART IDENTITY CASCADE;")
+    if not dry_run:
+        print(f"[OK] Cleared {table_name}")
+    return {"dry_run": dry_run, "tables": report}
 
 
 def seed():
@@ -150,11 +191,13 @@ def main():
     subparsers.add_parser("reset-db", help="Drop and recreate schema (clean everything)")
     subparsers.add_parser("migrate", help="Run migrations only")
     subparsers.add_parser("reset-and-seed", help="reset-db + migrate + seed (full clean setup)")
-    subparsers.add_parser("clear-all", help="Truncate all tables (keep schema)")
+    clear_all_parser = subparsers.add_parser("clear-all", help="Truncate all tables (keep schema)")
+    clear_all_parser.add_argument("--dry-run", action="store_true", help="Report what would be cleared, change nothing")
     subparsers.add_parser("status", help="Show table counts")
     subparsers.add_parser("seed", help="Seed from data/*.csv")
     clear_parser = subparsers.add_parser("clear-table", help="Clear one table")
-    clear_parser.add_argument("table", choices=["cards", "decks", "deck_cards"])
+    clear_parser.add_argument("table", choices=CLEARABLE_TABLES)
+    clear_parser.add_argument("--dry-run", action="store_true", help="Report what would be cleared, change nothing")
 
     args = parser.parse_args()
 
@@ -165,9 +208,9 @@ def main():
     elif args.command == "reset-and-seed":
         reset_and_seed()
     elif args.command == "clear-all":
-        clear_all_tables()
+        clear_all_tables(dry_run=args.dry_run)
     elif args.command == "clear-table":
-        clear_table(args.table)
+        clear_table(args.table, dry_run=args.dry_run)
     elif args.command == "status":
         show_status()
     elif args.command == "seed":
@@ -66,18 +66,38 @@ def parse_migration_filename(filename):
 
 
 def get_migration_files(migration_dir):
-    """Get all migration files sorted by version."""
+    """Get all migration files sorted by version.
+
+    Every .sql file must parse as V{version}__{description}.sql and have a
+    unique version; otherwise the run stops before anything is applied, since
+    a misnamed or colliding file would run in an undefined order.
+    """
     migration_path = Path(migration_dir)
     if not migration_path.exists():
         print(f"[ERROR] Migration directory not found: {migration_dir}", file=sys.stderr)
         sys.exit(1)
 
     migrations = []
-    for file_path in migration_path.glob("V*.sql"):
+    invalid_names = []
+    for file_path in sorted(migration_path.glob("*.sql")):
         migration_info = parse_migration_filename(file_path.name)
         if migration_info:
             migration_info["path"] = file_path
             migrations.append(migration_info)
+        else:
+            invalid_names.append(file_path.name)
+
+    by_version = {}
+    for migration in migrations:
+        by_version.setdefault(migration["version"], []).append(migration["filename"])
+    collisions = {version: names for version, names in by_version.items() if len(names) > 1}
+
+    if invalid_names or collisions:
+        for name in invalid_names:
+            print(f"[ERROR] Invalid migration filename (expected V<version>__<description>.sql): {name}", file=sys.stderr)
+        for version, names in sorted(collisions.items()):
+            print(f"[ERROR] Duplicate migration version {version}: {', '.join(names)}", file=sys.stderr)
+        sys.exit(1)
 
     # Sort by version number
     migrations.sort(key=lambda x: x["version"])
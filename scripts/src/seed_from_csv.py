@@ -65,9 +65,10 @@ def seed_cards(conn, data_dir: Path) -> int:
             cur.execute(
                 """
                 INSERT INTO cards (id, name, description, image, type, attribute, race, level,
-                                   attack_points, defense_points, cost, rarity)
+                                   attack_points, defense_points, cost, rarity, created_at, updated_at)
                 VALUES (%(id)s, %(name)s, %(description)s, %(image)s, %(type)s, %(attribute)s,
-                        %(race)s, %(level)s, %(attack_points)s, %(defense_points)s, %(cost)s, %(rarity)s)
+                        %(race)s, %(level)s, %(attack_points)s, %(defense_points)s, %(cost)s, %(rarity)s,
+                        NOW() AT TIME ZONE 'UTC', NOW() AT TIME ZONE 'UTC')
                 ON CONFLICT (id) DO UPDATE SET
                     name = EXCLUDED.name,
                     description = EXCLUDED.description,
@@ -80,7 +81,15 @@ def seed_cards(conn, data_dir: Path) -> int:
                     defense_points = EXCLUDED.defense_points,
                     cost = EXCLUDED.cost,
                     rarity = EXCLUDED.rarity,
-                    updated_at = NOW();
+                    updated_at = NOW() AT TIME ZONE 'UTC'
+                -- Only touch changed cards, so re-seeding keeps Last-Modified stable
+                WHERE (cards.name, cards.description, cards.image, cards.type, cards.attribute,
+                       cards.race, cards.level, cards.attack_points, cards.defense_points,
+                       cards.cost, cards.rarity)
+                      IS DISTINCT FROM
+                      (EXCLUDED.name, EXCLUDED.description, EXCLUDED.image, EXCLUDED.type,
+                       EXCLUDED.attribute, EXCLUDED.race, EXCLUDED.level, EXCLUDED.attack_points,
+                       EXCLUDED.defense_points, EXCLUDED.cost, EXCLUDED.rarity);
                 """,
                 {
                     "id": int(row["id"]),
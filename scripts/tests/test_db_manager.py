@@ -81,15 +81,49 @@ def test_reset_database_executes_expected_sql(patch_connection):
 
 
 def test_clear_all_tables_runs_truncate(patch_connection):
-    cursor = patch_connection()
-    db_manager.clear_all_tables()
+    # tables, then per table a COUNT(*) and (cards, decks) the first IDs
+    cursor = patch_connection(
+        fetchall_values=[[("cards",), ("decks",), ("deck_cards",)], [(1,), (2,)], [(7,)]],
+        fetchone_values=[(2,), (1,), (3,)],
+    )
+    result = db_manager.clear_all_tables()
     truncate = [s for s, _ in cursor.statements if "TRUNCATE TABLE" in s]
     assert len(truncate) == 1
     assert "RESTART IDENTITY CASCADE" in truncate[0]
+    assert result["dry_run"] is False
+    assert result["tables"]["cards"] == {"count": 2, "sample_ids": [1, 2]}
+
+
+def test_clear_all_tables_dry_run_reports_without_truncating(patch_connection, capsys):
+    cursor = patch_connection(
+        fetchall_values=[[("cards",), ("decks",), ("deck_cards",)], [(1,), (2,), (3,), (4,), (5,)], [(7,)]],
+        fetchone_values=[(900,), (1,), (40,)],
+    )
+    result = db_manager.clear_all_tables(dry_run=True)
+    assert not [s for s, _ in cursor.statements if "TRUNCATE TABLE" in s]
+    assert result == {
+        "dry_run": True,
+        "tables": {
+            "cards": {"count": 900, "sample_ids": [1, 2, 3, 4, 5]},
+            "decks": {"count": 1, "sample_ids": [7]},
+            "deck_cards": {"count": 40},
+        },
+    }
+    captured = capsys.readouterr()
+    assert "[DRY RUN] Would clear:" in captured.out
+    assert "cards: 900 rows (ids 1, 2, 3, 4, 5, ...)" in captured.out
+    assert "[OK] Cleared" not in captured.out
+
+
+def test_clear_table_dry_run_includes_cascaded_tables(patch_connection):
+    cursor = patch_connection(fetchone_values=[True, (1,), (40,)], fetchall_values=[[(7,)]])
+    result = db_manager.clear_table("decks", dry_run=True)
+    assert not [s for s, _ in cursor.statements if "TRUNCATE TABLE" in s]
+    assert result["tables"] == {"decks": {"count": 1, "sample_ids": [7]}, "deck_cards": {"count": 40}}
 
 
 def test_clear_table_valid_and_invalid(patch_connection, capsys):
-    patch_connection(fetchone_values=[True])
+    patch_connection(fetchone_values=[True, (2,), (0,)], fetchall_values=[[(1,), (2,)]])
     db_manager.clear_table("cards")
 
     patch_connection(fetchone_values=[False])
@@ -128,14 +162,22 @@ def test_main_migrate(monkeypatch):
 
 
 def test_main_other_commands(monkeypatch, patch_connection):
-    patch_connection()
+    patch_connection(
+        fetchall_values=[[("cards",), ("decks",), ("deck_cards",)], [], []],
+        fetchone_values=[(0,), (0,), (0,)],
+    )
     monkeypatch.setattr(sys, "argv", ["db_manager.py", "clear-all"])
     db_manager.main()
 
-    patch_connection(fetchone_values=[True])
+    patch_connection(fetchone_values=[True, (0,), (0,)], fetchall_values=[[]])
     monkeypatch.setattr(sys, "argv", ["db_manager.py", "clear-table", "cards"])
     db_manager.main()
 
+    cursor = patch_connection(fetchone_values=[True, (0,), (0,)], fetchall_values=[[]])
+    monkeypatch.setattr(sys, "argv", ["db_manager.py", "clear-table", "cards", "--dry-run"])
+    db_manager.main()
+    assert not [s for s, _ in cursor.statements if "TRUNCATE TABLE" in s]
+
     patch_connection()
     monkeypatch.setattr(sys, "argv", ["db_manager.py", "reset-db"])
     db_manager.main()
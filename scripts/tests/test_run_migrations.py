@@ -497,23 +497,31 @@ def test_main_nonexistent_dir(monkeypatch, capsys):
     assert "Migration directory not found" in captured.err
 
 
-def test_get_migration_files_filters_invalid_names(tmp_path):
-    """Test get_migration_files filters out files with invalid names (covers branch 78->76)."""
-    # Create valid migration file
-    valid_file = tmp_path / "V1__valid.sql"
-    valid_file.write_text("CREATE TABLE test;")
-    
-    # Create invalid file that matches V*.sql pattern but has invalid format
-    # (no double underscore, so parse_migration_filename returns None)
-    invalid_file = tmp_path / "Vinvalid.sql"  # Matches V*.sql but invalid format
-    invalid_file.write_text("CREATE TABLE test2;")
-    
-    migrations = run_migrations.get_migration_files(str(tmp_path))
-    
-    # Should only include the valid file (invalid one is filtered out by the if statement)
-    assert len(migrations) == 1
-    assert migrations[0]["version"] == 1
-    assert migrations[0]["description"] == "valid"
+def test_get_migration_files_rejects_invalid_names(tmp_path, capsys):
+    """Test get_migration_files exits on a .sql file that is not V<version>__<description>.sql."""
+    (tmp_path / "V1__valid.sql").write_text("CREATE TABLE test;")
+    # Matches *.sql but has no version prefix, so it would have an undefined order
+    (tmp_path / "add_index.sql").write_text("CREATE INDEX idx ON test (id);")
+
+    with pytest.raises(SystemExit) as exc_info:
+        run_migrations.get_migration_files(str(tmp_path))
+    assert exc_info.value.code == 1
+    captured = capsys.readouterr()
+    assert "Invalid migration filename" in captured.err
+    assert "add_index.sql" in captured.err
+
+
+def test_get_migration_files_rejects_version_collision(tmp_path, capsys):
+    """Test get_migration_files exits when two files share a version number."""
+    (tmp_path / "V1__initial_schema.sql").write_text("")
+    (tmp_path / "V01__base.sql").write_text("")
+    (tmp_path / "V2__second.sql").write_text("")
+
+    with pytest.raises(SystemExit) as exc_info:
+        run_migrations.get_migration_files(str(tmp_path))
+    assert exc_info.value.code == 1
+    captured = capsys.readouterr()
+    assert "Duplicate migration version 1: V01__base.sql, V1__initial_schema.sql" in captured.err
 
 
 def test_main_entry_point_execution(monkeypatch):
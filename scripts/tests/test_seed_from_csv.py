@@ -92,6 +92,24 @@ def test_seed_cards_inserts_rows(tmp_path, monkeypatch):
     assert "Test Card" in str(cursor.statements)
 
 
+def test_seed_cards_only_bumps_updated_at_on_change(tmp_path):
+    """seed_cards stamps new cards and leaves unchanged ones alone on re-seed."""
+    cards_csv = tmp_path / "cards.csv"
+    cards_csv.write_text(
+        "id,name,type,attribute,race,level,attack_points,defense_points,cost,rarity,description,image\n"
+        "1,Test Card,Normal Monster,LIGHT,Dragon,4,1500,1200,4,Common,,"
+    )
+    cursor = StubCursor()
+
+    seed_from_csv.seed_cards(StubConnection(cursor), tmp_path)
+    insert = next(s for s, _ in cursor.statements if "INSERT INTO cards" in s)
+    assert "created_at, updated_at" in insert
+    assert "IS DISTINCT FROM" in insert
+    # updated_at is served as Last-Modified in UTC, whatever the session's zone
+    assert "NOW(), NOW()" not in insert
+    assert "updated_at = NOW() AT TIME ZONE 'UTC'" in insert
+
+
 def test_seed_cards_missing_file(tmp_path, capsys):
     """seed_cards exits when cards.csv missing."""
     cursor = StubCursor()